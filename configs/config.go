@@ -2,12 +2,19 @@ package configs
 
 import (
 	_ "embed"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"math/big"
 	"os"
+	"sort"
 	"strings"
+	"sync"
 
 	"github.com/compose-network/dome/internal/logger"
+	"github.com/compose-network/dome/internal/rollup"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"gopkg.in/yaml.v3"
 )
@@ -16,10 +23,18 @@ var (
 	//go:embed config.yaml
 	embeddedConfig []byte
 	Values         App
+
+	// parsedABIs caches ParsedABI results by ABI JSON, so a changed config is parsed afresh
+	parsedABIs sync.Map
+
+	contractABIsOnce sync.Once
+	contractABIs     ContractABIs
+	contractABIsErr  error
 )
 
 const (
 	configPathEnvVar = "CONFIG_PATH"
+	minChainConfigs  = 2
 
 	ChainNameRollupA ChainName = "rollup-a"
 	ChainNameRollupB ChainName = "rollup-b"
@@ -27,23 +42,67 @@ const (
 	ContractNameBridge   ContractName = "bridge"
 	ContractNamePingPong ContractName = "pingpong"
 	ContractNameToken    ContractName = "bridgeabletoken"
+
+	GasOpBridgeSend    GasOperation = "bridge-send"
+	GasOpBridgeReceive GasOperation = "bridge-receive"
+	GasOpMint          GasOperation = "mint"
+	GasOpApprove       GasOperation = "approve"
+	GasOpEthTransfer   GasOperation = "eth-transfer"
 )
 
+// defaultGasProfile holds the gas limits used for operations missing from the gas-profile config section.
+// A zero limit means the gas is estimated.
+var defaultGasProfile = GasProfile{
+	GasOpBridgeSend:    900000,
+	GasOpBridgeReceive: 900000,
+	GasOpMint:          0,
+	GasOpApprove:       0,
+	GasOpEthTransfer:   25000,
+}
+
 type (
 	ChainName    string
 	ContractName string
+	GasOperation string
 
 	App struct {
 		L2 L2 `yaml:"l2"`
+		// GasProfile overrides the gas limit of individual operations
+		GasProfile GasProfile `yaml:"gas-profile"`
+		// GasFees sets the EIP-1559 fees used instead of the rollup's suggestion, in gwei
+		GasFees GasFees `yaml:"gas-fees"`
 	}
 	L2 struct {
 		ChainConfigs map[ChainName]ChainConfig       `yaml:"chain-configs"`
 		Contracts    map[ContractName]ContractConfig `yaml:"contracts"`
+		// CoordinatorURL is an optional dedicated endpoint cross tx requests are submitted to
+		CoordinatorURL string `yaml:"coordinator-url"`
+		// CoordinatorHeaders are sent with every request to the coordinator-url
+		CoordinatorHeaders map[string]string `yaml:"coordinator-headers"`
 	}
 	ChainConfig struct {
-		ID     int64  `yaml:"id"`
-		RPCURL string `yaml:"rpc-url"`
-		PK     string `yaml:"pk"`
+		ID int64 `yaml:"id"`
+		// RPCURLs lists the chain's endpoints in failover order. A single string is also accepted.
+		RPCURLs RPCURLList `yaml:"rpc-url"`
+		// WSURL is an optional WebSocket endpoint used for subscriptions
+		WSURL string `yaml:"ws-url"`
+		PK    string `yaml:"pk"`
+		// Keystore is a path to a go-ethereum keystore JSON file, used instead of PK
+		Keystore   string `yaml:"keystore"`
+		Passphrase string `yaml:"passphrase"`
+		// Headers are sent with every RPC request to the chain, e.g. an Authorization bearer token
+		Headers map[string]string `yaml:"headers"`
+	}
+
+	RPCURLList []string
+
+	// GasProfile maps operations to the gas limit their transactions are sent with, 0 meaning estimated
+	GasProfile map[GasOperation]uint64
+
+	// GasFees holds gwei-denominated fee caps, 0 meaning suggested by the rollup
+	GasFees struct {
+		TipCapGwei int64 `yaml:"tip-cap-gwei"`
+		FeeCapGwei int64 `yaml:"fee-cap-gwei"`
 	}
 
 	ContractConfig struct {
@@ -53,63 +112,97 @@ type (
 )
 
 func init() {
+	var (
+		app *App
+		err error
+	)
 	configPath, isSet := os.LookupEnv(configPathEnvVar)
 	if !isSet {
 		logger.Info("%s was not set, will use configuration values from embedded config.yaml", configPathEnvVar)
-		if err := loadConfig(embeddedConfig); err != nil {
-			panic(err.Error())
-		}
-		return
+		app, err = LoadFromBytes(embeddedConfig)
+	} else {
+		logger.Info("%s environment variable set to: %s. Loading configuration", configPathEnvVar, configPath)
+		app, err = LoadFromPath(configPath)
 	}
-
-	logger.Info("%s environment variable set to: %s. Loading configuration", configPathEnvVar, configPath)
-	data, err := os.ReadFile(configPath)
 	if err != nil {
-		panic(fmt.Errorf("failed to read config file %s: %w", configPath, err))
+		panic(err)
 	}
+	setValues(app)
+}
 
-	if err := loadConfig(data); err != nil {
-		logger.Info("failed to load external config (%v), falling back to embedded config", err)
-		panic(err.Error())
-	}
+// ValidationError is returned by Load when a config parses but fails validation.
+// Err joins every validation failure found.
+type ValidationError struct {
+	Err error
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid config: " + e.Err.Error()
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
 }
 
-func loadConfig(data []byte) error {
-	if err := yaml.Unmarshal(data, &Values); err != nil {
-		return fmt.Errorf("failed to unmarshal config: %w", err)
+// Load parses and validates a YAML config without touching Values.
+// Validation failures are returned as a *ValidationError.
+func Load(data []byte) (*App, error) {
+	var app App
+	if err := yaml.Unmarshal(data, &app); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
 
-	Values.normalizePrivateKeys()
+	app.normalizePrivateKeys()
+	app.applyGasProfileDefaults()
+
+	if err := app.validate(); err != nil {
+		return nil, &ValidationError{Err: err}
+	}
+	return &app, nil
+}
+
+// LoadFromBytes is Load under a name matching LoadFromPath
+func LoadFromBytes(data []byte) (*App, error) {
+	return Load(data)
+}
 
-	if err := Values.validate(); err != nil {
-		return fmt.Errorf("invalid config: %w", err)
+// LoadFromPath reads, parses and validates the YAML config at path without touching Values
+func LoadFromPath(path string) (*App, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file %s: %w", path, err)
 	}
+	return Load(data)
+}
+
+// setValues makes app the global configuration and logs a summary of it
+func setValues(app *App) {
+	Values = *app
 
 	// Get ABI lengths for logging
 	bridgeABILen := len(Values.L2.Contracts[ContractNameBridge].ABI)
 	tokenABILen := len(Values.L2.Contracts[ContractNameToken].ABI)
 	pingPongABILen := len(Values.L2.Contracts[ContractNamePingPong].ABI)
 
+	var chains strings.Builder
+	for _, name := range Values.L2.ChainNames() {
+		cfg := Values.L2.ChainConfigs[name]
+		fmt.Fprintf(&chains, "\n\t\t\t%s: ID: %d, RPC: %s", name, cfg.ID, strings.Join(cfg.RPCURLs, ", "))
+	}
+
 	logger.
 		Info(`configuration loaded successfully.
-			RollupA_ID: %d,
-			RollupA_RPC: %s
-			RollupB_ID: %d,
-			RollupB_RPC: %s
+			Chains:%s
 			Bridge_Address: %s (ABI: %d bytes)
 			Token_Address: %s (ABI: %d bytes)
 			PingPong_Address: %s (ABI: %d bytes)`,
-			Values.L2.ChainConfigs[ChainNameRollupA].ID,
-			Values.L2.ChainConfigs[ChainNameRollupA].RPCURL,
-			Values.L2.ChainConfigs[ChainNameRollupB].ID,
-			Values.L2.ChainConfigs[ChainNameRollupB].RPCURL,
+			chains.String(),
 			Values.L2.Contracts[ContractNameBridge].Address.Hex(),
 			bridgeABILen,
 			Values.L2.Contracts[ContractNameToken].Address.Hex(),
 			tokenABILen,
 			Values.L2.Contracts[ContractNamePingPong].Address.Hex(),
 			pingPongABILen)
-	return nil
 }
 
 func (a *App) validate() error {
@@ -123,30 +216,52 @@ func (a *App) validate() error {
 		err = errors.Join(err, contractsErr)
 	}
 
+	for op := range a.GasProfile {
+		if _, ok := defaultGasProfile[op]; !ok {
+			err = errors.Join(err, fmt.Errorf("field: 'gas-profile', operation '%s' is unknown", op))
+		}
+	}
+
+	if a.GasFees.TipCapGwei < 0 || a.GasFees.FeeCapGwei < 0 {
+		err = errors.Join(err, fmt.Errorf("field: 'gas-fees', fee caps must not be negative"))
+	}
+	if a.GasFees.TipCapGwei > 0 && a.GasFees.FeeCapGwei > 0 && a.GasFees.FeeCapGwei < a.GasFees.TipCapGwei {
+		err = errors.Join(err, fmt.Errorf("field: 'gas-fees', fee-cap-gwei %d is below tip-cap-gwei %d", a.GasFees.FeeCapGwei, a.GasFees.TipCapGwei))
+	}
+
 	return err
 }
 
 func (a *App) validateChainConfig() error {
 	var err error
-	if len(a.L2.ChainConfigs) != 2 {
-		err = errors.Join(err, fmt.Errorf("exactly two chain configs must be provided"))
-	}
-	if _, ok := a.L2.ChainConfigs[ChainNameRollupA]; !ok {
-		err = errors.Join(err, fmt.Errorf("chain config for '%s' must be provided", ChainNameRollupA))
-	}
-	if _, ok := a.L2.ChainConfigs[ChainNameRollupB]; !ok {
-		err = errors.Join(err, fmt.Errorf("chain config for '%s' must be provided", ChainNameRollupB))
+	if len(a.L2.ChainConfigs) < minChainConfigs {
+		err = errors.Join(err, fmt.Errorf("at least %d chain configs must be provided", minChainConfigs))
 	}
 
-	for name, cfg := range a.L2.ChainConfigs {
+	chainIDs := make(map[int64]ChainName, len(a.L2.ChainConfigs))
+	for _, name := range a.L2.ChainNames() {
+		cfg := a.L2.ChainConfigs[name]
+		if other, ok := chainIDs[cfg.ID]; ok && cfg.ID != 0 {
+			err = errors.Join(err, fmt.Errorf("field: 'id', chain: '%s', duplicates the id of chain '%s'", name, other))
+		}
+		chainIDs[cfg.ID] = name
+
 		if cfg.ID == 0 {
 			err = errors.Join(err, fmt.Errorf("field: 'id', chain: '%s', must be set and non-zero", name))
 		}
-		if cfg.RPCURL == "" {
+		if len(cfg.RPCURLs) == 0 {
 			err = errors.Join(err, fmt.Errorf("field: 'rpc-url', chain: '%s', must be set and non-zero", name))
 		}
-		if cfg.PK == "" {
-			err = errors.Join(err, fmt.Errorf("field: 'pk', chain: '%s', must be set and non-zero", name))
+		for i, url := range cfg.RPCURLs {
+			if url == "" {
+				err = errors.Join(err, fmt.Errorf("field: 'rpc-url', chain: '%s', entry %d must be non-empty", name, i))
+			}
+		}
+		if cfg.PK == "" && cfg.Keystore == "" {
+			err = errors.Join(err, fmt.Errorf("field: 'pk', chain: '%s', either 'pk' or 'keystore' must be set", name))
+		}
+		if cfg.PK != "" && cfg.Keystore != "" {
+			err = errors.Join(err, fmt.Errorf("field: 'keystore', chain: '%s', must not be set together with 'pk'", name))
 		}
 	}
 
@@ -180,6 +295,149 @@ func (a *App) validateContractsConfig() error {
 	return err
 }
 
+// UnmarshalYAML accepts either a single URL or a list of URLs
+func (l *RPCURLList) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		var url string
+		if err := node.Decode(&url); err != nil {
+			return err
+		}
+		*l = RPCURLList{url}
+		return nil
+	}
+
+	var urls []string
+	if err := node.Decode(&urls); err != nil {
+		return err
+	}
+	*l = urls
+	return nil
+}
+
+// ChainNames returns the names of all configured chains in sorted order.
+func (l L2) ChainNames() []ChainName {
+	names := make([]ChainName, 0, len(l.ChainConfigs))
+	for name := range l.ChainConfigs {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+	return names
+}
+
+// HeadersForURL returns the headers of the chain that lists rpcURL among its endpoints, the coordinator-headers
+// if rpcURL is the coordinator-url, or nil if neither matches
+func (l L2) HeadersForURL(rpcURL string) map[string]string {
+	for _, name := range l.ChainNames() {
+		cfg := l.ChainConfigs[name]
+		for _, url := range cfg.RPCURLs {
+			if url == rpcURL {
+				return cfg.Headers
+			}
+		}
+	}
+	if l.CoordinatorURL != "" && l.CoordinatorURL == rpcURL {
+		return l.CoordinatorHeaders
+	}
+	return nil
+}
+
+// ChainByID returns the name and config of the chain with the given ID
+func (l L2) ChainByID(id int64) (ChainName, ChainConfig, bool) {
+	for _, name := range l.ChainNames() {
+		if cfg := l.ChainConfigs[name]; cfg.ID == id {
+			return name, cfg, true
+		}
+	}
+	return "", ChainConfig{}, false
+}
+
+// RollupByID builds a Rollup for the chain with the given ID from its config.
+// Each call returns a new Rollup, which the caller should Close.
+func (l L2) RollupByID(id int64) (*rollup.Rollup, bool) {
+	name, cfg, ok := l.ChainByID(id)
+	if !ok {
+		return nil, false
+	}
+	return rollup.NewWithFallbacks(cfg.RPCURLs, big.NewInt(cfg.ID), string(name)).WithHeaders(cfg.Headers).WithWSURL(cfg.WSURL), true
+}
+
+// ParsedABI parses the ABI of contract name, caching the result.
+// Errors name the contract and, for malformed JSON, the offset of the syntax error.
+func (l L2) ParsedABI(name ContractName) (abi.ABI, error) {
+	contract, ok := l.Contracts[name]
+	if !ok {
+		return abi.ABI{}, fmt.Errorf("contract %s is not configured", name)
+	}
+	if strings.TrimSpace(contract.ABI) == "" {
+		return abi.ABI{}, fmt.Errorf("%s ABI is empty", name)
+	}
+	if cached, ok := parsedABIs.Load(contract.ABI); ok {
+		return cached.(abi.ABI), nil
+	}
+
+	parsed, err := abi.JSON(strings.NewReader(contract.ABI))
+	if err != nil {
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			return abi.ABI{}, fmt.Errorf("%s ABI is invalid JSON at offset %d: %w", name, syntaxErr.Offset, err)
+		}
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return abi.ABI{}, fmt.Errorf("%s ABI is truncated JSON: %w", name, err)
+		}
+		return abi.ABI{}, fmt.Errorf("failed to parse %s ABI: %w", name, err)
+	}
+	parsedABIs.Store(contract.ABI, parsed)
+	return parsed, nil
+}
+
+// ContractABIs holds the parsed ABIs of the configured contracts
+type ContractABIs struct {
+	Bridge   abi.ABI
+	Token    abi.ABI
+	PingPong abi.ABI
+}
+
+// ABIs parses the ABIs of all configured contracts on first use and returns the same result afterwards
+func ABIs() (ContractABIs, error) {
+	contractABIsOnce.Do(func() {
+		for _, contract := range []struct {
+			name ContractName
+			dst  *abi.ABI
+		}{
+			{ContractNameBridge, &contractABIs.Bridge},
+			{ContractNameToken, &contractABIs.Token},
+			{ContractNamePingPong, &contractABIs.PingPong},
+		} {
+			parsed, err := Values.L2.ParsedABI(contract.name)
+			if err != nil {
+				contractABIsErr = errors.Join(contractABIsErr, err)
+				continue
+			}
+			*contract.dst = parsed
+		}
+	})
+	return contractABIs, contractABIsErr
+}
+
+// Gas returns the gas limit configured for op, 0 meaning it should be estimated
+func (p GasProfile) Gas(op GasOperation) uint64 {
+	if gas, ok := p[op]; ok {
+		return gas
+	}
+	return defaultGasProfile[op]
+}
+
+func (a *App) applyGasProfileDefaults() {
+	if a.GasProfile == nil {
+		a.GasProfile = make(GasProfile, len(defaultGasProfile))
+	}
+	for op, gas := range defaultGasProfile {
+		if _, ok := a.GasProfile[op]; !ok {
+			a.GasProfile[op] = gas
+		}
+	}
+}
+
 func stripHexPrefix(s string) string {
 	return strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X")
 }
@@ -0,0 +1,284 @@
+package configs
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func validContracts() map[ContractName]ContractConfig {
+	return map[ContractName]ContractConfig{
+		ContractNameBridge:   {Address: common.HexToAddress("0x01"), ABI: "[]"},
+		ContractNamePingPong: {Address: common.HexToAddress("0x02"), ABI: "[]"},
+		ContractNameToken:    {Address: common.HexToAddress("0x03"), ABI: "[]"},
+	}
+}
+
+func TestValidateChainConfigAcceptsMoreThanTwoChains(t *testing.T) {
+	app := App{L2: L2{
+		ChainConfigs: map[ChainName]ChainConfig{
+			"rollup-c": {ID: 3, RPCURLs: RPCURLList{"http://localhost:38545"}, PK: "03"},
+			"rollup-a": {ID: 1, RPCURLs: RPCURLList{"http://localhost:18545"}, PK: "01"},
+			"rollup-b": {ID: 2, RPCURLs: RPCURLList{"http://localhost:28545"}, PK: "02"},
+		},
+		Contracts: validContracts(),
+	}}
+
+	require.NoError(t, app.validate())
+	require.Equal(t, []ChainName{"rollup-a", "rollup-b", "rollup-c"}, app.L2.ChainNames())
+}
+
+func TestValidateChainConfigRejectsSingleChain(t *testing.T) {
+	app := App{L2: L2{
+		ChainConfigs: map[ChainName]ChainConfig{
+			"rollup-a": {ID: 1, RPCURLs: RPCURLList{"http://localhost:18545"}, PK: "01"},
+		},
+		Contracts: validContracts(),
+	}}
+
+	require.ErrorContains(t, app.validate(), "at least 2 chain configs must be provided")
+}
+
+func TestValidateChainConfigRejectsDuplicateIDs(t *testing.T) {
+	app := App{L2: L2{
+		ChainConfigs: map[ChainName]ChainConfig{
+			"rollup-a": {ID: 1, RPCURLs: RPCURLList{"http://localhost:18545"}, PK: "01"},
+			"rollup-b": {ID: 1, RPCURLs: RPCURLList{"http://localhost:28545"}, PK: "02"},
+		},
+		Contracts: validContracts(),
+	}}
+
+	require.ErrorContains(t, app.validate(), "duplicates the id of chain 'rollup-a'")
+}
+
+func TestValidateChainConfigKeystore(t *testing.T) {
+	app := App{L2: L2{
+		ChainConfigs: map[ChainName]ChainConfig{
+			"rollup-a": {ID: 1, RPCURLs: RPCURLList{"http://localhost:18545"}, Keystore: "/keys/a.json", Passphrase: "secret"},
+			"rollup-b": {ID: 2, RPCURLs: RPCURLList{"http://localhost:28545"}, PK: "02"},
+		},
+		Contracts: validContracts(),
+	}}
+	require.NoError(t, app.validate())
+
+	app.L2.ChainConfigs["rollup-b"] = ChainConfig{ID: 2, RPCURLs: RPCURLList{"http://localhost:28545"}, PK: "02", Keystore: "/keys/b.json"}
+	require.ErrorContains(t, app.validate(), "field: 'keystore', chain: 'rollup-b', must not be set together with 'pk'")
+
+	app.L2.ChainConfigs["rollup-b"] = ChainConfig{ID: 2, RPCURLs: RPCURLList{"http://localhost:28545"}}
+	require.ErrorContains(t, app.validate(), "field: 'pk', chain: 'rollup-b', either 'pk' or 'keystore' must be set")
+}
+
+func TestRPCURLListUnmarshalsStringOrList(t *testing.T) {
+	var cfg struct {
+		Single ChainConfig `yaml:"single"`
+		Multi  ChainConfig `yaml:"multi"`
+	}
+	data := []byte(`
+single:
+  rpc-url: http://localhost:18545
+multi:
+  rpc-url:
+    - http://localhost:18545
+    - http://localhost:18546
+`)
+	require.NoError(t, yaml.Unmarshal(data, &cfg))
+	require.Equal(t, RPCURLList{"http://localhost:18545"}, cfg.Single.RPCURLs)
+	require.Equal(t, RPCURLList{"http://localhost:18545", "http://localhost:18546"}, cfg.Multi.RPCURLs)
+}
+
+func TestGasProfileDefaultsAndOverrides(t *testing.T) {
+	app := App{L2: L2{
+		ChainConfigs: map[ChainName]ChainConfig{
+			"rollup-a": {ID: 1, RPCURLs: RPCURLList{"http://localhost:18545"}, PK: "01"},
+			"rollup-b": {ID: 2, RPCURLs: RPCURLList{"http://localhost:28545"}, PK: "02"},
+		},
+		Contracts: validContracts(),
+	}}
+	require.NoError(t, yaml.Unmarshal([]byte("gas-profile:\n  bridge-send: 1200000\n"), &app))
+	app.applyGasProfileDefaults()
+
+	require.NoError(t, app.validate())
+	require.Equal(t, uint64(1200000), app.GasProfile.Gas(GasOpBridgeSend))
+	require.Equal(t, uint64(900000), app.GasProfile.Gas(GasOpBridgeReceive))
+	require.Equal(t, uint64(25000), app.GasProfile.Gas(GasOpEthTransfer))
+	require.Zero(t, app.GasProfile.Gas(GasOpMint))
+
+	app.GasProfile["bridge-sned"] = 1
+	require.ErrorContains(t, app.validate(), "field: 'gas-profile', operation 'bridge-sned' is unknown")
+}
+
+func TestGasFeesValidation(t *testing.T) {
+	app := App{L2: L2{
+		ChainConfigs: map[ChainName]ChainConfig{
+			"rollup-a": {ID: 1, RPCURLs: RPCURLList{"http://localhost:18545"}, PK: "01"},
+			"rollup-b": {ID: 2, RPCURLs: RPCURLList{"http://localhost:28545"}, PK: "02"},
+		},
+		Contracts: validContracts(),
+	}}
+	require.NoError(t, yaml.Unmarshal([]byte("gas-fees:\n  tip-cap-gwei: 2\n  fee-cap-gwei: 30\n"), &app))
+	require.NoError(t, app.validate())
+	require.Equal(t, GasFees{TipCapGwei: 2, FeeCapGwei: 30}, app.GasFees)
+
+	app.GasFees.FeeCapGwei = 1
+	require.ErrorContains(t, app.validate(), "field: 'gas-fees', fee-cap-gwei 1 is below tip-cap-gwei 2")
+}
+
+func TestParsedABI(t *testing.T) {
+	l2 := L2{Contracts: validContracts()}
+	l2.Contracts[ContractNameToken] = ContractConfig{ABI: `[{"type":"function","name":"mint","inputs":[],"outputs":[]}]`}
+	l2.Contracts[ContractNameBridge] = ContractConfig{ABI: `[{"type":"function",}]`}
+	l2.Contracts[ContractNamePingPong] = ContractConfig{}
+
+	parsed, err := l2.ParsedABI(ContractNameToken)
+	require.NoError(t, err)
+	require.Contains(t, parsed.Methods, "mint")
+	cached, err := l2.ParsedABI(ContractNameToken)
+	require.NoError(t, err)
+	require.Equal(t, parsed, cached)
+
+	_, err = l2.ParsedABI(ContractNameBridge)
+	require.ErrorContains(t, err, "bridge ABI is invalid JSON at offset 21")
+	l2.Contracts[ContractNameBridge] = ContractConfig{ABI: `[{"type":"function",`}
+	_, err = l2.ParsedABI(ContractNameBridge)
+	require.ErrorContains(t, err, "bridge ABI is truncated JSON")
+	_, err = l2.ParsedABI(ContractNamePingPong)
+	require.ErrorContains(t, err, "pingpong ABI is empty")
+	_, err = l2.ParsedABI("vault")
+	require.ErrorContains(t, err, "contract vault is not configured")
+}
+
+func TestABIsParsesOnce(t *testing.T) {
+	previous := Values.L2.Contracts
+	t.Cleanup(func() {
+		Values.L2.Contracts = previous
+		contractABIsOnce, contractABIs, contractABIsErr = sync.Once{}, ContractABIs{}, nil
+	})
+	contractABIsOnce = sync.Once{}
+	Values.L2.Contracts = validContracts()
+	Values.L2.Contracts[ContractNameToken] = ContractConfig{ABI: `[{"type":"function","name":"mint","inputs":[],"outputs":[]}]`}
+
+	parsed, err := ABIs()
+	require.NoError(t, err)
+	require.Contains(t, parsed.Token.Methods, "mint")
+
+	// later config changes are not picked up
+	Values.L2.Contracts[ContractNameToken] = ContractConfig{}
+	again, err := ABIs()
+	require.NoError(t, err)
+	require.Equal(t, parsed, again)
+}
+
+func TestLoadReturnsValidationError(t *testing.T) {
+	app, err := Load([]byte(`
+l2:
+  chain-configs:
+    rollup-a:
+      id: 1
+      rpc-url: http://localhost:18545
+      pk: 0x01
+`))
+	require.Nil(t, app)
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	require.ErrorContains(t, err, "at least 2 chain configs must be provided")
+
+	_, err = Load([]byte("l2: ["))
+	require.Error(t, err)
+	require.False(t, errors.As(err, &validationErr))
+}
+
+const validConfigYAML = `
+l2:
+  chain-configs:
+    rollup-a: {id: 1, rpc-url: http://localhost:18545, pk: 0x01}
+    rollup-b: {id: 2, rpc-url: http://localhost:28545, pk: "02"}
+  contracts:
+    bridge: {address: "0x1111111111111111111111111111111111111111", abi: "[]"}
+    pingpong: {address: "0x2222222222222222222222222222222222222222", abi: "[]"}
+    bridgeabletoken: {address: "0x3333333333333333333333333333333333333333", abi: "[]"}
+`
+
+func TestLoadNormalizesValidConfig(t *testing.T) {
+	app, err := Load([]byte(validConfigYAML))
+	require.NoError(t, err)
+	require.Equal(t, "01", app.L2.ChainConfigs["rollup-a"].PK)
+	require.Equal(t, uint64(900000), app.GasProfile[GasOpBridgeSend])
+}
+
+func TestLoadFromPathAndBytes(t *testing.T) {
+	dir := t.TempDir()
+	validPath := filepath.Join(dir, "valid.yaml")
+	require.NoError(t, os.WriteFile(validPath, []byte(validConfigYAML), 0o600))
+	invalidPath := filepath.Join(dir, "invalid.yaml")
+	require.NoError(t, os.WriteFile(invalidPath, []byte("l2:\n  chain-configs: {}\n"), 0o600))
+	before := Values
+
+	tests := []struct {
+		name    string
+		load    func() (*App, error)
+		wantErr string
+	}{
+		{name: "bytes", load: func() (*App, error) { return LoadFromBytes([]byte(validConfigYAML)) }},
+		{name: "path", load: func() (*App, error) { return LoadFromPath(validPath) }},
+		{name: "invalid path", load: func() (*App, error) { return LoadFromPath(invalidPath) }, wantErr: "invalid config"},
+		{name: "missing path", load: func() (*App, error) { return LoadFromPath(filepath.Join(dir, "missing.yaml")) }, wantErr: "failed to read config file"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app, err := tt.load()
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, app.L2.ChainConfigs, 2)
+		})
+	}
+	require.Equal(t, before, Values)
+}
+
+func TestChainAndRollupByID(t *testing.T) {
+	l2 := L2{ChainConfigs: map[ChainName]ChainConfig{
+		"rollup-a": {ID: 77777, RPCURLs: RPCURLList{"http://localhost:18545", "http://backup:18545"}, PK: "01"},
+		"rollup-b": {ID: 88888, RPCURLs: RPCURLList{"http://localhost:28545"}, PK: "02", Headers: map[string]string{"Authorization": "Bearer x"}},
+	}}
+
+	name, cfg, ok := l2.ChainByID(88888)
+	require.True(t, ok)
+	require.Equal(t, ChainName("rollup-b"), name)
+	require.Equal(t, "02", cfg.PK)
+
+	r, ok := l2.RollupByID(77777)
+	require.True(t, ok)
+	defer r.Close()
+	require.Equal(t, "rollup-a", r.Name())
+	require.Equal(t, int64(77777), r.ChainID().Int64())
+	require.Equal(t, "http://localhost:18545", r.RPCURL())
+
+	_, _, ok = l2.ChainByID(1)
+	require.False(t, ok)
+	_, ok = l2.RollupByID(1)
+	require.False(t, ok)
+}
+
+func TestHeadersForURL(t *testing.T) {
+	l2 := L2{
+		ChainConfigs: map[ChainName]ChainConfig{
+			"rollup-a": {ID: 77777, RPCURLs: RPCURLList{"http://localhost:18545"}},
+			"rollup-b": {ID: 88888, RPCURLs: RPCURLList{"http://localhost:28545"}, Headers: map[string]string{"Authorization": "Bearer chain"}},
+		},
+		CoordinatorURL:     "http://coordinator:8080",
+		CoordinatorHeaders: map[string]string{"Authorization": "Bearer coordinator"},
+	}
+
+	require.Equal(t, map[string]string{"Authorization": "Bearer chain"}, l2.HeadersForURL("http://localhost:28545"))
+	require.Equal(t, map[string]string{"Authorization": "Bearer coordinator"}, l2.HeadersForURL("http://coordinator:8080"))
+	require.Nil(t, l2.HeadersForURL("http://localhost:18545"))
+	require.Nil(t, l2.HeadersForURL("http://unknown:8080"))
+}
@@ -16,6 +16,10 @@ func TestConfigPrivateKeysAreValidForECDSA(t *testing.T) {
 
 	for chainName, cfg := range Values.L2.ChainConfigs {
 		t.Run(string(chainName), func(t *testing.T) {
+			if cfg.Keystore != "" {
+				t.Skipf("chain %s uses a keystore instead of a private key", chainName)
+			}
+
 			// Attempt to create ECDSA private key from the normalized PK
 			privateKey, err := crypto.HexToECDSA(cfg.PK)
 			if err != nil {
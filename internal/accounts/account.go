@@ -12,23 +12,20 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
 )
 
 type Account struct {
 	privateKey *ecdsa.PrivateKey
 	address    common.Address
 	onRollup   *rollup.Rollup
-	client     *ethclient.Client
+	nonces     *NonceManager
+	cache      balanceCache
 }
 
-// NewRollupAccount creates a new blockchain account
+// NewRollupAccount creates a new blockchain account.
+// The account does not own a connection: it uses the shared client of onRollup, which is dialed on first use.
 func NewRollupAccount(privateKeyHex string, onRollup *rollup.Rollup) (*Account, error) {
-	client, err := ethclient.Dial(onRollup.RPCURL())
-	if err != nil {
-		return nil, fmt.Errorf("failed to connect to blockchain: %w", err)
-	}
-
 	privateKey, err := crypto.HexToECDSA(privateKeyHex)
 	if err != nil {
 		return nil, fmt.Errorf("invalid private key: %w", err)
@@ -36,12 +33,13 @@ func NewRollupAccount(privateKeyHex string, onRollup *rollup.Rollup) (*Account,
 
 	address := crypto.PubkeyToAddress(privateKey.PublicKey)
 
-	return &Account{
+	ac := &Account{
 		privateKey: privateKey,
 		address:    address,
 		onRollup:   onRollup,
-		client:     client,
-	}, nil
+	}
+	ac.nonces = newNonceManager(ac)
+	return ac, nil
 }
 
 // GetAddress returns the address derived from the private key
@@ -56,47 +54,114 @@ func (ac *Account) GetRollup() *rollup.Rollup {
 
 // GetBalance returns the balance of the account
 func (ac *Account) GetBalance(ctx context.Context) (*big.Int, error) {
+	client, err := ac.onRollup.Client(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	address := ac.GetAddress()
-	balance, err := ac.client.BalanceAt(ctx, address, nil)
+	balance, err := client.BalanceAt(ctx, address, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get balance: %w", err)
 	}
 	return balance, nil
 }
 
-// GetNonce returns the nonce for the next transaction
+// GetNonce returns the nonce for the next transaction, counting transactions still in the mempool
 func (ac *Account) GetNonce(ctx context.Context) (uint64, error) {
+	return ac.GetNonceAt(ctx, rpc.PendingBlockNumber)
+}
+
+// GetNonceAt returns the account's nonce at blockTag, e.g. rpc.PendingBlockNumber or rpc.LatestBlockNumber.
+// The latest nonce only counts mined transactions, so it is lower than the pending one while sends are in flight.
+func (ac *Account) GetNonceAt(ctx context.Context, blockTag rpc.BlockNumber) (uint64, error) {
+	client, err := ac.onRollup.Client(ctx)
+	if err != nil {
+		return 0, err
+	}
+
 	address := ac.GetAddress()
-	nonce, err := ac.client.PendingNonceAt(ctx, address)
+	nonce, err := client.NonceAt(ctx, address, big.NewInt(int64(blockTag)))
 	if err != nil {
-		return 0, fmt.Errorf("failed to get nonce: %w", err)
+		return 0, fmt.Errorf("failed to get %s nonce: %w", blockTag, err)
 	}
-	logger.Info("Nonce loaded successfully for account: %s with nonce: %d", address.Hex(), nonce)
+	logger.Info("Nonce loaded successfully for account: %s with %s nonce: %d", address.Hex(), blockTag, nonce)
 	return nonce, nil
 }
 
+// Nonces returns the account's nonce manager.
+// Transactions built with CreateTransaction do not go through it, so call Reset before relying on it after such sends.
+func (ac *Account) Nonces() *NonceManager {
+	return ac.nonces
+}
+
 func (ac *Account) GetPrivateKey() *ecdsa.PrivateKey {
 	return ac.privateKey
 }
 
-// Close closes the blockchain client connection
-func (ac *Account) Close() {
-	if ac.client != nil {
-		ac.client.Close()
+// Close is a no-op kept for compatibility: accounts share their rollup's client instead of owning one.
+// Use CloseAll or rollup.Close to release connections.
+func (ac *Account) Close() {}
+
+// CloseAll closes the shared clients of the rollups the given accounts are on.
+// The rollups dial a new client on next use, so this is safe to call while other accounts remain in use.
+func CloseAll(accs []*Account) {
+	closed := make(map[*rollup.Rollup]struct{}, len(accs))
+	for _, ac := range accs {
+		if _, ok := closed[ac.onRollup]; ok {
+			continue
+		}
+		closed[ac.onRollup] = struct{}{}
+		ac.onRollup.Close()
 	}
 }
 
+// CallContract calls the read-only method of the contract at contractAddress and unpacks the result into out.
+// out is a pointer to the single return value, or a pointer to a struct whose fields match the outputs
+// for methods with several return values.
+func (ac *Account) CallContract(ctx context.Context, contractAddress common.Address, contractABI abi.ABI, method string, out interface{}, args ...interface{}) error {
+	return ac.callContractAt(ctx, nil, contractAddress, contractABI, method, out, args...)
+}
+
+// callContractAt is CallContract against the state at blockNumber, or the latest state if it is nil
+func (ac *Account) callContractAt(ctx context.Context, blockNumber *big.Int, contractAddress common.Address, contractABI abi.ABI, method string, out interface{}, args ...interface{}) error {
+	client, err := ac.onRollup.Client(ctx)
+	if err != nil {
+		return err
+	}
+
+	contract := bind.NewBoundContract(contractAddress, contractABI, client, client, client)
+	call := &bind.CallOpts{Context: ctx, From: ac.GetAddress(), BlockNumber: blockNumber}
+
+	if err := contract.Call(call, &[]interface{}{out}, method, args...); err != nil {
+		return fmt.Errorf("failed to call %s on %s at %s: %w", method, ac.onRollup.Name(), contractAddress.Hex(), err)
+	}
+	return nil
+}
+
 func (ac *Account) GetTokensBalance(ctx context.Context, contractAddress common.Address, contractABI abi.ABI) (*big.Int, error) {
 	ownerAddr := ac.GetAddress()
-	contract := bind.NewBoundContract(contractAddress, contractABI, ac.client, ac.client, ac.client)
-	call := &bind.CallOpts{Context: ctx}
 
 	var balance *big.Int
-	if err := contract.Call(call, &[]interface{}{&balance}, "balanceOf", ownerAddr); err != nil {
-		logger.Error("failed to get tokens balance on %s for account: %s: %w", ac.onRollup.Name(), ownerAddr.Hex(), err)
+	if err := ac.CallContract(ctx, contractAddress, contractABI, "balanceOf", &balance, ownerAddr); err != nil {
+		logger.Error("failed to get tokens balance on %s for account: %s: %v", ac.onRollup.Name(), ownerAddr.Hex(), err)
 		return nil, err
 	}
 	logger.Info("Tokens balance loaded successfully on %s for account: %s with balance: %d", ac.onRollup.Name(), ownerAddr.Hex(), balance)
 
 	return balance, nil
 }
+
+// GetTokenAllowance returns how many tokens spender is allowed to spend on behalf of the account
+func (ac *Account) GetTokenAllowance(ctx context.Context, contractAddress common.Address, spender common.Address, contractABI abi.ABI) (*big.Int, error) {
+	ownerAddr := ac.GetAddress()
+
+	var allowance *big.Int
+	if err := ac.CallContract(ctx, contractAddress, contractABI, "allowance", &allowance, ownerAddr, spender); err != nil {
+		logger.Error("failed to get token allowance on %s for account: %s and spender: %s: %v", ac.onRollup.Name(), ownerAddr.Hex(), spender.Hex(), err)
+		return nil, err
+	}
+	logger.Info("Token allowance loaded successfully on %s for account: %s and spender: %s with allowance: %d", ac.onRollup.Name(), ownerAddr.Hex(), spender.Hex(), allowance)
+
+	return allowance, nil
+}
@@ -0,0 +1,142 @@
+package accounts
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/compose-network/dome/internal/rollup"
+	"github.com/compose-network/dome/internal/rpctest"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPrivateKeyHex = "4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"
+
+const viewABI = `[
+	{"type":"function","name":"balanceOf","stateMutability":"view","inputs":[{"name":"owner","type":"address"}],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"session","stateMutability":"view","inputs":[],"outputs":[{"name":"id","type":"uint256"},{"name":"owner","type":"address"}]}
+]`
+
+// newCallServer starts a JSON-RPC server for chain 77777 answering every eth_call with result
+func newCallServer(t *testing.T, result []byte) *httptest.Server {
+	t.Helper()
+	return rpctest.NewServer(t, func(req rpctest.Request) interface{} {
+		if req.Method == "eth_chainId" {
+			return rpctest.ChainID
+		}
+		assert.Equal(t, "eth_call", req.Method)
+		return hexutil.Encode(result)
+	})
+}
+
+func TestCallContract(t *testing.T) {
+	contractABI, err := abi.JSON(strings.NewReader(viewABI))
+	require.NoError(t, err)
+	owner := common.HexToAddress("0x02")
+
+	balanceData, err := contractABI.Methods["balanceOf"].Outputs.Pack(big.NewInt(42))
+	require.NoError(t, err)
+	ac, err := NewRollupAccount(testPrivateKeyHex, rollup.New(newCallServer(t, balanceData).URL, big.NewInt(77777), "test-rollup"))
+	require.NoError(t, err)
+	defer ac.Close()
+
+	var balance *big.Int
+	require.NoError(t, ac.CallContract(t.Context(), common.HexToAddress("0x01"), contractABI, "balanceOf", &balance, owner))
+	require.Equal(t, big.NewInt(42), balance)
+
+	sessionData, err := contractABI.Methods["session"].Outputs.Pack(big.NewInt(7), owner)
+	require.NoError(t, err)
+	ac, err = NewRollupAccount(testPrivateKeyHex, rollup.New(newCallServer(t, sessionData).URL, big.NewInt(77777), "test-rollup"))
+	require.NoError(t, err)
+	defer ac.Close()
+
+	var session struct {
+		ID    *big.Int `abi:"id"`
+		Owner common.Address
+	}
+	require.NoError(t, ac.CallContract(t.Context(), common.HexToAddress("0x01"), contractABI, "session", &session))
+	require.Equal(t, big.NewInt(7), session.ID)
+	require.Equal(t, owner, session.Owner)
+
+	err = ac.CallContract(t.Context(), common.HexToAddress("0x01"), contractABI, "missing", &balance)
+	require.ErrorContains(t, err, "failed to call missing on test-rollup")
+}
+
+func TestAccountsShareRollupClient(t *testing.T) {
+	r := rollup.New(newCallServer(t, nil).URL, big.NewInt(77777), "test-rollup")
+	acA, err := NewRollupAccount(testPrivateKeyHex, r)
+	require.NoError(t, err)
+	acB, err := NewRollupAccount(testPrivateKeyHex, r)
+	require.NoError(t, err)
+
+	client, err := r.Client(t.Context())
+	require.NoError(t, err)
+	acA.Close()
+	sameClient, err := r.Client(t.Context())
+	require.NoError(t, err)
+	require.Same(t, client, sameClient, "closing an account must not close the shared client")
+
+	CloseAll([]*Account{acA, acB})
+	redialed, err := r.Client(t.Context())
+	require.NoError(t, err)
+	require.NotSame(t, client, redialed)
+	r.Close()
+}
+
+func TestGetNonceAtPendingAndLatest(t *testing.T) {
+	server := rpctest.NewServer(t, func(req rpctest.Request) interface{} {
+		if req.Method == "eth_getTransactionCount" {
+			// one transaction is still in the mempool
+			return map[string]string{`"pending"`: "0x4", `"latest"`: "0x3"}[string(req.Params[1])]
+		}
+		return rpctest.ChainID
+	})
+	ac, err := NewRollupAccount(testPrivateKeyHex, rollup.New(server.URL, big.NewInt(77777), "test-rollup"))
+	require.NoError(t, err)
+
+	pending, err := ac.GetNonce(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), pending)
+	latest, err := ac.GetNonceAt(t.Context(), rpc.LatestBlockNumber)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), latest)
+}
+
+func TestGetNonceRespectsContextDeadline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		if req.Method == "eth_getTransactionCount" {
+			// simulate a hanging RPC
+			<-r.Context().Done()
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": "0x12fd1"}))
+	}))
+	t.Cleanup(server.Close)
+	r := rollup.New(server.URL, big.NewInt(77777), "test-rollup")
+	t.Cleanup(r.Close)
+	_, err := r.Client(t.Context())
+	require.NoError(t, err)
+	ac, err := NewRollupAccount(testPrivateKeyHex, r)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+	_, err = ac.GetNonce(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,84 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/compose-network/dome/internal/rollup"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// BatchTokenBalances returns the token balance of every account, in input order, using one batched
+// JSON-RPC round trip per rollup. Balances that could not be fetched are nil in the result, and their
+// errors are joined into the returned error.
+func BatchTokenBalances(ctx context.Context, accs []*Account, token common.Address, tokenABI abi.ABI) ([]*big.Int, error) {
+	// group account indexes by rollup so each rollup gets a single batch
+	byRollup := make(map[*rollup.Rollup][]int)
+	for i, ac := range accs {
+		byRollup[ac.onRollup] = append(byRollup[ac.onRollup], i)
+	}
+
+	balances := make([]*big.Int, len(accs))
+	results := make([]hexutil.Bytes, len(accs))
+	callErrs := make([]error, len(accs))
+	for _, indexes := range byRollup {
+		batch := make([]rpc.BatchElem, len(indexes))
+		for j, i := range indexes {
+			data, err := tokenABI.Pack("balanceOf", accs[i].GetAddress())
+			if err != nil {
+				return nil, fmt.Errorf("failed to pack balanceOf calldata: %w", err)
+			}
+			batch[j] = rpc.BatchElem{
+				Method: "eth_call",
+				Args: []interface{}{
+					map[string]interface{}{"to": token, "data": hexutil.Bytes(data)},
+					"latest",
+				},
+				Result: &results[i],
+			}
+		}
+
+		client, err := accs[indexes[0]].onRollup.Client(ctx)
+		if err == nil {
+			err = client.Client().BatchCallContext(ctx, batch)
+		}
+		if err != nil {
+			for _, i := range indexes {
+				callErrs[i] = err
+			}
+			continue
+		}
+		for j, i := range indexes {
+			callErrs[i] = batch[j].Error
+		}
+	}
+
+	var errs error
+	for i, ac := range accs {
+		if callErrs[i] != nil {
+			errs = errors.Join(errs, fmt.Errorf("account %d (%s) on %s: %w", i, ac.GetAddress().Hex(), ac.onRollup.Name(), callErrs[i]))
+			continue
+		}
+		out, err := tokenABI.Methods["balanceOf"].Outputs.Unpack(results[i])
+		if err == nil && len(out) != 1 {
+			err = fmt.Errorf("expected 1 output, got %d", len(out))
+		}
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("account %d (%s) on %s: failed to unpack balance: %w", i, ac.GetAddress().Hex(), ac.onRollup.Name(), err))
+			continue
+		}
+		balance, ok := out[0].(*big.Int)
+		if !ok {
+			errs = errors.Join(errs, fmt.Errorf("account %d (%s) on %s: unexpected balanceOf output type %T", i, ac.GetAddress().Hex(), ac.onRollup.Name(), out[0]))
+			continue
+		}
+		balances[i] = balance
+	}
+
+	return balances, errs
+}
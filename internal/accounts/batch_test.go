@@ -0,0 +1,68 @@
+package accounts
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/compose-network/dome/internal/rollup"
+	"github.com/compose-network/dome/internal/rpctest"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchTokenBalances(t *testing.T) {
+	tokenABI, err := abi.JSON(strings.NewReader(viewABI))
+	require.NoError(t, err)
+	token := common.HexToAddress("0x3333333333333333333333333333333333333333")
+
+	var callsA atomic.Int32
+	balancesA := make(map[common.Address]int64)
+	serverA := rpctest.NewServer(t, func(req rpctest.Request) interface{} {
+		if req.Method == "eth_chainId" {
+			return rpctest.ChainID
+		}
+		assert.Equal(t, "eth_call", req.Method)
+		callsA.Add(1)
+		var call struct {
+			To   common.Address `json:"to"`
+			Data hexutil.Bytes  `json:"data"`
+		}
+		assert.NoError(t, json.Unmarshal(req.Params[0], &call))
+		assert.Equal(t, token, call.To)
+		data, err := tokenABI.Methods["balanceOf"].Outputs.Pack(big.NewInt(balancesA[common.BytesToAddress(call.Data[4:])]))
+		assert.NoError(t, err)
+		return hexutil.Encode(data)
+	})
+	serverB := rpctest.NewServer(t, func(req rpctest.Request) interface{} {
+		if req.Method == "eth_chainId" {
+			return "0x15b38"
+		}
+		return &rpctest.Error{Code: 3, Message: "execution reverted"}
+	})
+	rollupA := rollup.New(serverA.URL, big.NewInt(77777), "rollup-a")
+	rollupB := rollup.New(serverB.URL, big.NewInt(88888), "rollup-b")
+
+	otherKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	a1, err := NewRollupAccount(testPrivateKeyHex, rollupA)
+	require.NoError(t, err)
+	b1, err := NewRollupAccount(testPrivateKeyHex, rollupB)
+	require.NoError(t, err)
+	a2, err := NewRollupAccount(hexutil.Encode(crypto.FromECDSA(otherKey))[2:], rollupA)
+	require.NoError(t, err)
+	balancesA[a1.GetAddress()] = 100
+	balancesA[a2.GetAddress()] = 200
+
+	balances, err := BatchTokenBalances(t.Context(), []*Account{a1, b1, a2}, token, tokenABI)
+	require.ErrorContains(t, err, "account 1 ("+b1.GetAddress().Hex()+") on rollup-b: execution reverted")
+	require.NotContains(t, err.Error(), "account 0")
+	require.Equal(t, []*big.Int{big.NewInt(100), nil, big.NewInt(200)}, balances)
+	require.Equal(t, int32(2), callsA.Load())
+}
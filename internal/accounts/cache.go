@@ -0,0 +1,54 @@
+package accounts
+
+import (
+	"context"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// balanceCache holds token balances read at a single pinned block
+type balanceCache struct {
+	mu       sync.Mutex
+	block    *big.Int
+	balances map[common.Address]*big.Int
+}
+
+// GetTokensBalanceCached returns the account's balance of token like GetTokensBalance, but reads it at a block
+// pinned on first use and serves repeated reads from memory.
+// The cache is only valid while no transactions affecting the account are mined; call InvalidateCache after sending any.
+func (ac *Account) GetTokensBalanceCached(ctx context.Context, token common.Address, tokenABI abi.ABI) (*big.Int, error) {
+	ac.cache.mu.Lock()
+	defer ac.cache.mu.Unlock()
+
+	if ac.cache.block == nil {
+		blockNumber, err := ac.onRollup.BlockNumber(ctx)
+		if err != nil {
+			return nil, err
+		}
+		ac.cache.block = new(big.Int).SetUint64(blockNumber)
+		ac.cache.balances = make(map[common.Address]*big.Int)
+	}
+
+	if balance, ok := ac.cache.balances[token]; ok {
+		return new(big.Int).Set(balance), nil
+	}
+
+	var balance *big.Int
+	if err := ac.callContractAt(ctx, ac.cache.block, token, tokenABI, "balanceOf", &balance, ac.GetAddress()); err != nil {
+		return nil, err
+	}
+	ac.cache.balances[token] = balance
+	return new(big.Int).Set(balance), nil
+}
+
+// InvalidateCache drops all cached balances so the next cached read pins the latest block again
+func (ac *Account) InvalidateCache() {
+	ac.cache.mu.Lock()
+	defer ac.cache.mu.Unlock()
+
+	ac.cache.block = nil
+	ac.cache.balances = nil
+}
@@ -0,0 +1,71 @@
+package accounts
+
+import (
+	"math/big"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/compose-network/dome/internal/rollup"
+	"github.com/compose-network/dome/internal/rpctest"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTokensBalanceCached(t *testing.T) {
+	tokenABI, err := abi.JSON(strings.NewReader(viewABI))
+	require.NoError(t, err)
+	balanceData, err := tokenABI.Methods["balanceOf"].Outputs.Pack(big.NewInt(42))
+	require.NoError(t, err)
+
+	var (
+		blockCalls, ethCalls atomic.Int32
+		callBlock            atomic.Value
+	)
+	server := rpctest.NewServer(t, func(req rpctest.Request) interface{} {
+		switch req.Method {
+		case "eth_chainId":
+			return rpctest.ChainID
+		case "eth_blockNumber":
+			return hexutil.EncodeUint64(uint64(0x10 + blockCalls.Add(1) - 1))
+		case "eth_call":
+			ethCalls.Add(1)
+			callBlock.Store(string(req.Params[1]))
+			return hexutil.Encode(balanceData)
+		}
+		t.Errorf("unexpected method %s", req.Method)
+		return nil
+	})
+
+	ac, err := NewRollupAccount(testPrivateKeyHex, rollup.New(server.URL, big.NewInt(77777), "test-rollup"))
+	require.NoError(t, err)
+	defer ac.Close()
+	token := common.HexToAddress("0x01")
+
+	for range 3 {
+		balance, err := ac.GetTokensBalanceCached(t.Context(), token, tokenABI)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(42), balance)
+	}
+	require.Equal(t, int32(1), blockCalls.Load())
+	require.Equal(t, int32(1), ethCalls.Load())
+	require.Equal(t, `"0x10"`, callBlock.Load())
+
+	// returned balances are copies, so callers cannot corrupt the cache
+	balance, err := ac.GetTokensBalanceCached(t.Context(), token, tokenABI)
+	require.NoError(t, err)
+	balance.SetInt64(0)
+	balance, err = ac.GetTokensBalanceCached(t.Context(), token, tokenABI)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(42), balance)
+
+	// after invalidation the balance is read again at the new head
+	ac.InvalidateCache()
+	_, err = ac.GetTokensBalanceCached(t.Context(), token, tokenABI)
+	require.NoError(t, err)
+	require.Equal(t, int32(2), blockCalls.Load())
+	require.Equal(t, int32(2), ethCalls.Load())
+	require.Equal(t, `"0x11"`, callBlock.Load())
+}
@@ -0,0 +1,41 @@
+package accounts
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/compose-network/dome/configs"
+	"github.com/compose-network/dome/internal/rollup"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// DefaultAccounts builds the funded account of every chain in configs.Values, each on its own rollup, keyed by chain name.
+// Accounts come from the chain's pk, or from its keystore when one is configured.
+func DefaultAccounts() (map[configs.ChainName]*Account, error) {
+	chainConfigs := configs.Values.L2.ChainConfigs
+	defaults := make(map[configs.ChainName]*Account, len(chainConfigs))
+	for _, name := range configs.Values.L2.ChainNames() {
+		cfg := chainConfigs[name]
+		onRollup := rollup.NewWithFallbacks(cfg.RPCURLs, big.NewInt(cfg.ID), string(name)).WithHeaders(cfg.Headers).WithWSURL(cfg.WSURL)
+
+		ac, err := defaultAccount(cfg, onRollup)
+		if err != nil {
+			for _, created := range defaults {
+				created.Close()
+			}
+			return nil, fmt.Errorf("failed to create default account for chain %s: %w", name, err)
+		}
+		defaults[name] = ac
+	}
+	return defaults, nil
+}
+
+func defaultAccount(cfg configs.ChainConfig, onRollup *rollup.Rollup) (*Account, error) {
+	if cfg.Keystore != "" {
+		return NewRollupAccountFromKeystore(cfg.Keystore, cfg.Passphrase, onRollup)
+	}
+	if _, err := crypto.HexToECDSA(cfg.PK); err != nil {
+		return nil, fmt.Errorf("field 'pk' is not a valid ECDSA private key: %w", err)
+	}
+	return NewRollupAccount(cfg.PK, onRollup)
+}
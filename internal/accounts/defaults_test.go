@@ -0,0 +1,26 @@
+package accounts
+
+import (
+	"testing"
+
+	"github.com/compose-network/dome/configs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultAccountsCoverEveryChain(t *testing.T) {
+	defaults, err := DefaultAccounts()
+	require.NoError(t, err)
+
+	require.Len(t, defaults, len(configs.Values.L2.ChainConfigs))
+	for name, ac := range defaults {
+		cfg := configs.Values.L2.ChainConfigs[name]
+		require.Equal(t, string(name), ac.GetRollup().Name())
+		require.Equal(t, cfg.ID, ac.GetRollup().ChainID().Int64())
+		ac.Close()
+	}
+}
+
+func TestDefaultAccountRejectsInvalidPK(t *testing.T) {
+	_, err := defaultAccount(configs.ChainConfig{ID: 1, RPCURLs: configs.RPCURLList{"http://127.0.0.1:0"}, PK: "zz"}, nil)
+	require.ErrorContains(t, err, "field 'pk' is not a valid ECDSA private key")
+}
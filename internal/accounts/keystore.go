@@ -0,0 +1,42 @@
+package accounts
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/compose-network/dome/internal/rollup"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// NewRollupAccountFromKeystore creates an account from an encrypted go-ethereum keystore JSON file.
+// It fails if the decrypted key does not match the address recorded in the keystore.
+func NewRollupAccountFromKeystore(keystorePath, passphrase string, onRollup *rollup.Rollup) (*Account, error) {
+	keyJSON, err := os.ReadFile(keystorePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keystore %s: %w", keystorePath, err)
+	}
+
+	var header struct {
+		Address string `json:"address"`
+	}
+	if err := json.Unmarshal(keyJSON, &header); err != nil {
+		return nil, fmt.Errorf("failed to parse keystore %s: %w", keystorePath, err)
+	}
+	if !common.IsHexAddress(header.Address) {
+		return nil, fmt.Errorf("keystore %s has an invalid address field %q", keystorePath, header.Address)
+	}
+
+	key, err := keystore.DecryptKey(keyJSON, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt keystore %s: %w", keystorePath, err)
+	}
+	if expected := common.HexToAddress(header.Address); key.Address != expected {
+		return nil, fmt.Errorf("keystore %s decrypts to %s, expected %s", keystorePath, key.Address.Hex(), expected.Hex())
+	}
+
+	return NewRollupAccount(hex.EncodeToString(crypto.FromECDSA(key.PrivateKey)), onRollup)
+}
@@ -0,0 +1,60 @@
+package accounts
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/compose-network/dome/internal/rollup"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// writeKeystore encrypts a fresh key with passphrase and writes it to a temp file, returning the path and address
+func writeKeystore(t *testing.T, passphrase string) (string, common.Address) {
+	t.Helper()
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	key := &keystore.Key{
+		Id:         uuid.New(),
+		Address:    crypto.PubkeyToAddress(privateKey.PublicKey),
+		PrivateKey: privateKey,
+	}
+	keyJSON, err := keystore.EncryptKey(key, passphrase, keystore.LightScryptN, keystore.LightScryptP)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "key.json")
+	require.NoError(t, os.WriteFile(path, keyJSON, 0o600))
+	return path, key.Address
+}
+
+func TestNewRollupAccountFromKeystore(t *testing.T) {
+	r := rollup.New("http://127.0.0.1:0", big.NewInt(77777), "test-rollup")
+	path, address := writeKeystore(t, "secret")
+
+	ac, err := NewRollupAccountFromKeystore(path, "secret", r)
+	require.NoError(t, err)
+	defer ac.Close()
+	require.Equal(t, address, ac.GetAddress())
+
+	_, err = NewRollupAccountFromKeystore(path, "wrong", r)
+	require.ErrorContains(t, err, "failed to decrypt keystore")
+}
+
+func TestNewRollupAccountFromKeystoreRejectsAddressMismatch(t *testing.T) {
+	r := rollup.New("http://127.0.0.1:0", big.NewInt(77777), "test-rollup")
+	path, address := writeKeystore(t, "secret")
+
+	keyJSON, err := os.ReadFile(path)
+	require.NoError(t, err)
+	tampered := strings.Replace(string(keyJSON), strings.ToLower(address.Hex()[2:]), "0000000000000000000000000000000000000001", 1)
+	require.NoError(t, os.WriteFile(path, []byte(tampered), 0o600))
+
+	_, err = NewRollupAccountFromKeystore(path, "secret", r)
+	require.ErrorContains(t, err, "expected 0x0000000000000000000000000000000000000001")
+}
@@ -0,0 +1,116 @@
+package accounts
+
+import (
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/pbkdf2"
+	"crypto/sha512"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/compose-network/dome/internal/rollup"
+	gethaccounts "github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// DefaultDerivationPathFormat is the standard Ethereum BIP-44 path, indexed by account number
+const DefaultDerivationPathFormat = "m/44'/60'/0'/0/%d"
+
+// NewRollupAccountFromMnemonic creates an account from a BIP-39 mnemonic and a BIP-32 derivation path such as m/44'/60'/0'/0/0.
+// The mnemonic words are not checked against the BIP-39 wordlist and no passphrase is used.
+func NewRollupAccountFromMnemonic(mnemonic string, path string, onRollup *rollup.Rollup) (*Account, error) {
+	derivationPath, err := gethaccounts.ParseDerivationPath(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid derivation path %q: %w", path, err)
+	}
+
+	seed, err := mnemonicToSeed(mnemonic)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, err := deriveKey(seed, derivationPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key for path %s: %w", path, err)
+	}
+
+	return NewRollupAccount(hex.EncodeToString(crypto.FromECDSA(privateKey)), onRollup)
+}
+
+// DeriveN creates count accounts from a mnemonic using DefaultDerivationPathFormat with indexes 0..count-1
+func DeriveN(mnemonic string, count int, onRollup *rollup.Rollup) ([]*Account, error) {
+	accs := make([]*Account, 0, count)
+	for i := range count {
+		ac, err := NewRollupAccountFromMnemonic(mnemonic, fmt.Sprintf(DefaultDerivationPathFormat, i), onRollup)
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive account %d: %w", i, err)
+		}
+		accs = append(accs, ac)
+	}
+	return accs, nil
+}
+
+// mnemonicToSeed computes the BIP-39 seed of a mnemonic with an empty passphrase
+func mnemonicToSeed(mnemonic string) ([]byte, error) {
+	normalized := strings.Join(strings.Fields(mnemonic), " ")
+	if normalized == "" {
+		return nil, fmt.Errorf("mnemonic must not be empty")
+	}
+	seed, err := pbkdf2.Key(sha512.New, normalized, []byte("mnemonic"), 2048, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute seed: %w", err)
+	}
+	return seed, nil
+}
+
+// deriveKey derives the BIP-32 private key for path from seed
+func deriveKey(seed []byte, path gethaccounts.DerivationPath) (*ecdsa.PrivateKey, error) {
+	curveOrder := crypto.S256().Params().N
+
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+	key, chainCode := new(big.Int).SetBytes(sum[:32]), sum[32:]
+	if key.Sign() == 0 || key.Cmp(curveOrder) >= 0 {
+		return nil, fmt.Errorf("invalid master key")
+	}
+
+	for _, index := range path {
+		var data []byte
+		if index >= 0x80000000 {
+			data = append([]byte{0x00}, crypto.FromECDSA(toECDSA(key))...)
+		} else {
+			data = crypto.CompressPubkey(&toECDSA(key).PublicKey)
+		}
+		data = binary.BigEndian.AppendUint32(data, index)
+
+		mac := hmac.New(sha512.New, chainCode)
+		mac.Write(data)
+		sum := mac.Sum(nil)
+
+		tweak := new(big.Int).SetBytes(sum[:32])
+		if tweak.Cmp(curveOrder) >= 0 {
+			return nil, fmt.Errorf("invalid child key at index %d", index)
+		}
+		key = new(big.Int).Mod(new(big.Int).Add(tweak, key), curveOrder)
+		if key.Sign() == 0 {
+			return nil, fmt.Errorf("invalid child key at index %d", index)
+		}
+		chainCode = sum[32:]
+	}
+
+	return toECDSA(key), nil
+}
+
+// toECDSA converts a secp256k1 scalar into a private key
+func toECDSA(key *big.Int) *ecdsa.PrivateKey {
+	privateKey, err := crypto.ToECDSA(key.FillBytes(make([]byte, 32)))
+	if err != nil {
+		// key is always in [1, n-1] here
+		panic(err)
+	}
+	return privateKey
+}
@@ -0,0 +1,43 @@
+package accounts
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/compose-network/dome/internal/rollup"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+// testMnemonic is the well known development mnemonic used by Hardhat and Anvil
+const testMnemonic = "test test test test test test test test test test test junk"
+
+func TestNewRollupAccountFromMnemonic(t *testing.T) {
+	r := rollup.New("http://127.0.0.1:0", big.NewInt(77777), "test-rollup")
+
+	ac, err := NewRollupAccountFromMnemonic(testMnemonic, "m/44'/60'/0'/0/0", r)
+	require.NoError(t, err)
+	defer ac.Close()
+	require.Equal(t, common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"), ac.GetAddress())
+}
+
+func TestDeriveN(t *testing.T) {
+	r := rollup.New("http://127.0.0.1:0", big.NewInt(77777), "test-rollup")
+
+	accs, err := DeriveN(testMnemonic, 3, r)
+	require.NoError(t, err)
+	require.Len(t, accs, 3)
+	for _, ac := range accs {
+		defer ac.Close()
+	}
+	require.Equal(t, common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"), accs[0].GetAddress())
+	require.Equal(t, common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8"), accs[1].GetAddress())
+	require.Equal(t, common.HexToAddress("0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC"), accs[2].GetAddress())
+}
+
+func TestNewRollupAccountFromMnemonicRejectsInvalidPath(t *testing.T) {
+	r := rollup.New("http://127.0.0.1:0", big.NewInt(77777), "test-rollup")
+
+	_, err := NewRollupAccountFromMnemonic(testMnemonic, "not/a/path", r)
+	require.ErrorContains(t, err, "invalid derivation path")
+}
@@ -0,0 +1,44 @@
+package accounts
+
+import (
+	"github.com/compose-network/dome/internal/rollup"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// MultiChainAccount holds one Account per rollup, all sharing the same private key and address
+type MultiChainAccount struct {
+	address  common.Address
+	accounts map[*rollup.Rollup]*Account
+}
+
+// NewMultiChainAccount creates an account for privateKeyHex on each of the given rollups
+func NewMultiChainAccount(privateKeyHex string, rollups ...*rollup.Rollup) (*MultiChainAccount, error) {
+	mca := &MultiChainAccount{accounts: make(map[*rollup.Rollup]*Account, len(rollups))}
+	for _, r := range rollups {
+		ac, err := NewRollupAccount(privateKeyHex, r)
+		if err != nil {
+			mca.Close()
+			return nil, err
+		}
+		mca.accounts[r] = ac
+		mca.address = ac.GetAddress()
+	}
+	return mca, nil
+}
+
+// On returns the account on rollup r, or nil if r was not passed to NewMultiChainAccount
+func (mca *MultiChainAccount) On(r *rollup.Rollup) *Account {
+	return mca.accounts[r]
+}
+
+// GetAddress returns the address shared by the account on every rollup
+func (mca *MultiChainAccount) GetAddress() common.Address {
+	return mca.address
+}
+
+// Close closes the account on every rollup. Like Account.Close it does not close the rollups' shared clients.
+func (mca *MultiChainAccount) Close() {
+	for _, ac := range mca.accounts {
+		ac.Close()
+	}
+}
@@ -0,0 +1,30 @@
+package accounts
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/compose-network/dome/internal/rollup"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMultiChainAccount(t *testing.T) {
+	rollupA := rollup.New("http://127.0.0.1:0", big.NewInt(77777), "rollup-a")
+	rollupB := rollup.New("http://127.0.0.1:0", big.NewInt(88888), "rollup-b")
+
+	mca, err := NewMultiChainAccount(testPrivateKeyHex, rollupA, rollupB)
+	require.NoError(t, err)
+	defer mca.Close()
+
+	onA, onB := mca.On(rollupA), mca.On(rollupB)
+	require.NotNil(t, onA)
+	require.NotNil(t, onB)
+	require.Same(t, rollupA, onA.GetRollup())
+	require.Same(t, rollupB, onB.GetRollup())
+	require.Equal(t, onA.GetAddress(), onB.GetAddress())
+	require.Equal(t, onA.GetAddress(), mca.GetAddress())
+	require.Nil(t, mca.On(rollup.New("http://127.0.0.1:0", big.NewInt(99999), "rollup-c")))
+
+	_, err = NewMultiChainAccount("not-a-key", rollupA, rollupB)
+	require.ErrorContains(t, err, "invalid private key")
+}
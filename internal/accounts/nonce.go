@@ -0,0 +1,87 @@
+package accounts
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+// NonceManager hands out monotonically increasing nonces for an account without querying the chain for each one.
+// The pending nonce is fetched on first use and after Reset; every Next call afterwards is served from memory.
+// To sign with a specific nonce instead, e.g. a deliberately wrong one, use transactions.CreateTransactionWithNonce or
+// the transactions.WithNonces bridge option; both bypass the manager.
+type NonceManager struct {
+	mu       sync.Mutex
+	account  *Account
+	next     uint64
+	released []uint64 // nonces handed back by Release, kept sorted
+	synced   bool
+}
+
+func newNonceManager(account *Account) *NonceManager {
+	return &NonceManager{account: account}
+}
+
+// Next returns the next nonce to use and reserves it
+func (nm *NonceManager) Next(ctx context.Context) (uint64, error) {
+	nm.mu.Lock()
+	defer nm.mu.Unlock()
+
+	if !nm.synced {
+		if err := nm.sync(ctx); err != nil {
+			return 0, err
+		}
+	}
+
+	if len(nm.released) > 0 {
+		nonce := nm.released[0]
+		nm.released = nm.released[1:]
+		return nonce, nil
+	}
+	nonce := nm.next
+	nm.next++
+	return nonce, nil
+}
+
+// Release returns a nonce obtained from Next to the pool, so the lowest released nonce is handed out again before new ones.
+// Only release a nonce whose transaction failed before reaching the mempool, e.g. a signing or RPC error on send.
+// Once a transaction was accepted by the node its nonce is consumed and releasing it would cause a "nonce too low" failure.
+func (nm *NonceManager) Release(nonce uint64) {
+	nm.mu.Lock()
+	defer nm.mu.Unlock()
+
+	if nonce >= nm.next {
+		return
+	}
+	i, found := slices.BinarySearch(nm.released, nonce)
+	if !found {
+		nm.released = slices.Insert(nm.released, i, nonce)
+	}
+}
+
+// Reset resyncs the manager with the account's pending nonce on chain
+func (nm *NonceManager) Reset(ctx context.Context) error {
+	nm.mu.Lock()
+	defer nm.mu.Unlock()
+
+	return nm.sync(ctx)
+}
+
+func (nm *NonceManager) sync(ctx context.Context) error {
+	nonce, err := nm.account.GetNonce(ctx)
+	if err != nil {
+		return err
+	}
+	nm.next = nonce
+	nm.released = nil
+	nm.synced = true
+	return nil
+}
+
+// invalidate makes the next Next call resync with the chain
+func (nm *NonceManager) invalidate() {
+	nm.mu.Lock()
+	defer nm.mu.Unlock()
+
+	nm.synced = false
+}
@@ -0,0 +1,111 @@
+package accounts
+
+import (
+	"math/big"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/compose-network/dome/internal/rollup"
+	"github.com/compose-network/dome/internal/rpctest"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNonceManagerReleaseReusesNonce(t *testing.T) {
+	server := rpctest.NewServer(t, func(req rpctest.Request) interface{} {
+		if req.Method == "eth_getTransactionCount" {
+			return "0x7"
+		}
+		return rpctest.ChainID
+	})
+	ac, err := NewRollupAccount(testPrivateKeyHex, rollup.New(server.URL, big.NewInt(77777), "test-rollup"))
+	require.NoError(t, err)
+	nm := ac.Nonces()
+
+	var allocated []uint64
+	for range 3 {
+		nonce, err := nm.Next(t.Context())
+		require.NoError(t, err)
+		allocated = append(allocated, nonce)
+	}
+	require.Equal(t, []uint64{7, 8, 9}, allocated)
+
+	// the send with nonce 8 failed before reaching the mempool
+	nm.Release(8)
+	nm.Release(8)
+	nm.Release(42) // never handed out, ignored
+
+	next, err := nm.Next(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, uint64(8), next)
+	next, err = nm.Next(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), next)
+}
+
+// newNonceAccount returns an account on a rollup whose pending nonce is read from pending on every query
+func newNonceAccount(t *testing.T, pending *atomic.Uint64, queries *atomic.Int32) *Account {
+	t.Helper()
+	server := rpctest.NewServer(t, func(req rpctest.Request) interface{} {
+		if req.Method == "eth_getTransactionCount" {
+			queries.Add(1)
+			return hexutil.Uint64(pending.Load())
+		}
+		return rpctest.ChainID
+	})
+	ac, err := NewRollupAccount(testPrivateKeyHex, rollup.New(server.URL, big.NewInt(77777), "test-rollup"))
+	require.NoError(t, err)
+	return ac
+}
+
+func TestNonceManagerNextIsMonotonicUnderConcurrency(t *testing.T) {
+	var pending atomic.Uint64
+	var queries atomic.Int32
+	pending.Store(7)
+	nm := newNonceAccount(t, &pending, &queries).Nonces()
+
+	const workers = 50
+	nonces := make([]uint64, workers)
+	errs := make([]error, workers)
+	var wg sync.WaitGroup
+	for i := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			nonces[i], errs[i] = nm.Next(t.Context())
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+	slices.Sort(nonces)
+	for i, nonce := range nonces {
+		require.Equal(t, uint64(7+i), nonce)
+	}
+	require.Equal(t, int32(1), queries.Load(), "the pending nonce is fetched once")
+}
+
+func TestNonceManagerResetResyncs(t *testing.T) {
+	var pending atomic.Uint64
+	var queries atomic.Int32
+	pending.Store(3)
+	nm := newNonceAccount(t, &pending, &queries).Nonces()
+
+	for want := uint64(3); want < 5; want++ {
+		nonce, err := nm.Next(t.Context())
+		require.NoError(t, err)
+		require.Equal(t, want, nonce)
+	}
+
+	// other senders moved the account on chain
+	pending.Store(20)
+	require.NoError(t, nm.Reset(t.Context()))
+	nonce, err := nm.Next(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, uint64(20), nonce)
+	require.Equal(t, int32(2), queries.Load())
+}
@@ -0,0 +1,68 @@
+package accounts
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/compose-network/dome/internal/rollup"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// FundFunc prepares freshly created pool accounts, e.g. by sending them ETH for gas and approving tokens
+type FundFunc func(ctx context.Context, accs []*MultiChainAccount) error
+
+// Pool hands out pre-funded accounts so that tests can reuse them instead of funding new ones every run.
+// It is safe for concurrent use.
+type Pool struct {
+	all       []*MultiChainAccount
+	available chan *MultiChainAccount
+}
+
+// NewPool creates size accounts with random keys on each of rollups and funds them all at once with fund
+func NewPool(ctx context.Context, size int, fund FundFunc, rollups ...*rollup.Rollup) (*Pool, error) {
+	p := &Pool{available: make(chan *MultiChainAccount, size)}
+	for range size {
+		pk, err := crypto.GenerateKey()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate pool account key: %w", err)
+		}
+		mca, err := NewMultiChainAccount(hex.EncodeToString(crypto.FromECDSA(pk)), rollups...)
+		if err != nil {
+			return nil, err
+		}
+		p.all = append(p.all, mca)
+	}
+
+	if err := fund(ctx, p.all); err != nil {
+		return nil, fmt.Errorf("failed to fund pool accounts: %w", err)
+	}
+	for _, mca := range p.all {
+		p.available <- mca
+	}
+	return p, nil
+}
+
+// Size returns the number of accounts in the pool, acquired or not
+func (p *Pool) Size() int {
+	return len(p.all)
+}
+
+// Acquire takes an account from the pool, waiting until one is released if all are in use
+func (p *Pool) Acquire(ctx context.Context) (*MultiChainAccount, error) {
+	select {
+	case mca := <-p.available:
+		return mca, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf("no pool account available: %w", ctx.Err())
+	}
+}
+
+// Release returns mca to the pool. Its nonce managers resync with the chain before their next use,
+// since the previous holder may have sent transactions bypassing them.
+func (p *Pool) Release(mca *MultiChainAccount) {
+	for _, ac := range mca.accounts {
+		ac.nonces.invalidate()
+	}
+	p.available <- mca
+}
@@ -0,0 +1,43 @@
+package accounts
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/compose-network/dome/internal/rollup"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPoolAcquireAndRelease(t *testing.T) {
+	rollupA := rollup.New("http://127.0.0.1:0", big.NewInt(77777), "rollup-a")
+	rollupB := rollup.New("http://127.0.0.1:0", big.NewInt(88888), "rollup-b")
+
+	var funded []*MultiChainAccount
+	pool, err := NewPool(t.Context(), 2, func(_ context.Context, accs []*MultiChainAccount) error {
+		funded = accs
+		return nil
+	}, rollupA, rollupB)
+	require.NoError(t, err)
+	require.Equal(t, 2, pool.Size())
+	require.Len(t, funded, 2)
+
+	first, err := pool.Acquire(t.Context())
+	require.NoError(t, err)
+	second, err := pool.Acquire(t.Context())
+	require.NoError(t, err)
+	require.NotEqual(t, first.GetAddress(), second.GetAddress())
+	require.NotNil(t, first.On(rollupB))
+
+	// every account is in use, so acquiring blocks until the context is done
+	ctx, cancel := context.WithTimeout(t.Context(), 20*time.Millisecond)
+	defer cancel()
+	_, err = pool.Acquire(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	pool.Release(first)
+	again, err := pool.Acquire(t.Context())
+	require.NoError(t, err)
+	require.Same(t, first, again)
+}
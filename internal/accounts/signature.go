@@ -0,0 +1,32 @@
+package accounts
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// RecoverSigner returns the address that produced the 65-byte signature sig over hash.
+// Both 0/1 and 27/28 recovery ids are accepted.
+func RecoverSigner(hash common.Hash, sig []byte) (common.Address, error) {
+	if len(sig) != crypto.SignatureLength {
+		return common.Address{}, fmt.Errorf("invalid signature length %d, expected %d", len(sig), crypto.SignatureLength)
+	}
+
+	normalized := make([]byte, crypto.SignatureLength)
+	copy(normalized, sig)
+	switch v := normalized[crypto.RecoveryIDOffset]; v {
+	case 0, 1:
+	case 27, 28:
+		normalized[crypto.RecoveryIDOffset] = v - 27
+	default:
+		return common.Address{}, fmt.Errorf("invalid signature recovery id %d", v)
+	}
+
+	pub, err := crypto.SigToPub(hash.Bytes(), normalized)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to recover signer: %w", err)
+	}
+	return crypto.PubkeyToAddress(*pub), nil
+}
@@ -0,0 +1,35 @@
+package accounts
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecoverSigner(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	expected := crypto.PubkeyToAddress(key.PublicKey)
+	hash := crypto.Keccak256Hash([]byte("message"))
+
+	sig, err := crypto.Sign(hash.Bytes(), key)
+	require.NoError(t, err)
+
+	signer, err := RecoverSigner(hash, sig)
+	require.NoError(t, err)
+	require.Equal(t, expected, signer)
+
+	// the same signature with a 27/28 recovery id, as produced by SignTypedData
+	sig[crypto.RecoveryIDOffset] += 27
+	signer, err = RecoverSigner(hash, sig)
+	require.NoError(t, err)
+	require.Equal(t, expected, signer)
+
+	sig[crypto.RecoveryIDOffset] = 5
+	_, err = RecoverSigner(hash, sig)
+	require.ErrorContains(t, err, "invalid signature recovery id 5")
+
+	_, err = RecoverSigner(hash, sig[:64])
+	require.ErrorContains(t, err, "invalid signature length 64")
+}
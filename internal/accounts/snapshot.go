@@ -0,0 +1,107 @@
+package accounts
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Delta is the change of an account's token and ETH balances since a BalanceSnapshot was taken
+type Delta struct {
+	Token *big.Int
+	Eth   *big.Int
+}
+
+// BalanceSnapshot records the token and ETH balances of a set of accounts so later changes can be asserted
+// relative to them. Accounts are keyed by address, so a snapshot should cover a single rollup.
+type BalanceSnapshot struct {
+	accs     []*Account
+	token    common.Address
+	tokenABI abi.ABI
+	tokens   []*big.Int
+	eth      []*big.Int
+}
+
+// NewBalanceSnapshot captures the current token and ETH balances of accs
+func NewBalanceSnapshot(ctx context.Context, token common.Address, tokenABI abi.ABI, accs ...*Account) (*BalanceSnapshot, error) {
+	seen := make(map[common.Address]struct{}, len(accs))
+	for _, ac := range accs {
+		if _, ok := seen[ac.GetAddress()]; ok {
+			return nil, fmt.Errorf("account %s appears more than once in the snapshot", ac.GetAddress().Hex())
+		}
+		seen[ac.GetAddress()] = struct{}{}
+	}
+
+	s := &BalanceSnapshot{accs: accs, token: token, tokenABI: tokenABI}
+	var err error
+	if s.tokens, s.eth, err = s.balances(ctx); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *BalanceSnapshot) balances(ctx context.Context) (tokens, eth []*big.Int, err error) {
+	tokens, err = BatchTokenBalances(ctx, s.accs, s.token, s.tokenABI)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get token balances: %w", err)
+	}
+	eth = make([]*big.Int, len(s.accs))
+	for i, ac := range s.accs {
+		if eth[i], err = ac.GetBalance(ctx); err != nil {
+			return nil, nil, fmt.Errorf("failed to get balance of %s: %w", ac.GetAddress().Hex(), err)
+		}
+	}
+	return tokens, eth, nil
+}
+
+// Diff returns the balance changes of every snapshotted account since the snapshot was taken
+func (s *BalanceSnapshot) Diff(ctx context.Context) (map[common.Address]Delta, error) {
+	tokens, eth, err := s.balances(ctx)
+	if err != nil {
+		return nil, err
+	}
+	deltas := make(map[common.Address]Delta, len(s.accs))
+	for i, ac := range s.accs {
+		deltas[ac.GetAddress()] = Delta{
+			Token: new(big.Int).Sub(tokens[i], s.tokens[i]),
+			Eth:   new(big.Int).Sub(eth[i], s.eth[i]),
+		}
+	}
+	return deltas, nil
+}
+
+// AssertDeltas fails t unless every address in expected changed by exactly its Delta since the snapshot.
+// All balances are queried once, however many addresses are checked. A nil Token or Eth skips that check,
+// e.g. when gas costs are not known up front.
+func (s *BalanceSnapshot) AssertDeltas(t testing.TB, expected map[common.Address]Delta) {
+	t.Helper()
+	deltas, err := s.Diff(t.Context())
+	if err != nil {
+		t.Fatalf("failed to diff balances: %v", err)
+	}
+
+	var mismatches []string
+	for addr, want := range expected {
+		delta, ok := deltas[addr]
+		if !ok {
+			mismatches = append(mismatches, fmt.Sprintf("account %s is not part of the snapshot", addr.Hex()))
+			continue
+		}
+		if want.Token != nil && delta.Token.Cmp(want.Token) != 0 {
+			mismatches = append(mismatches, fmt.Sprintf("token balance of %s changed by %s, expected %s", addr.Hex(), delta.Token, want.Token))
+		}
+		if want.Eth != nil && delta.Eth.Cmp(want.Eth) != 0 {
+			mismatches = append(mismatches, fmt.Sprintf("ETH balance of %s changed by %s, expected %s", addr.Hex(), delta.Eth, want.Eth))
+		}
+	}
+	if len(mismatches) > 0 {
+		slices.Sort(mismatches)
+		t.Fatalf("balances did not change as expected:\n%s", strings.Join(mismatches, "\n"))
+	}
+}
@@ -0,0 +1,84 @@
+package accounts
+
+import (
+	"math/big"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/compose-network/dome/internal/rollup"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+// balanceService is an eth namespace serving chain 77777 whose token and ETH balances can be changed by the test
+type balanceService struct {
+	tokenABI abi.ABI
+
+	mu     sync.Mutex
+	tokens map[common.Address]*big.Int
+	eth    map[common.Address]*big.Int
+}
+
+func (s *balanceService) ChainId() *hexutil.Big {
+	return (*hexutil.Big)(big.NewInt(77777))
+}
+
+func (s *balanceService) Call(args struct {
+	Data hexutil.Bytes `json:"data"`
+}, _ string) (hexutil.Bytes, error) {
+	in, err := s.tokenABI.Methods["balanceOf"].Inputs.Unpack(args.Data[4:])
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tokenABI.Methods["balanceOf"].Outputs.Pack(s.tokens[in[0].(common.Address)])
+}
+
+func (s *balanceService) GetBalance(addr common.Address, _ string) *hexutil.Big {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return (*hexutil.Big)(s.eth[addr])
+}
+
+func TestBalanceSnapshotDiff(t *testing.T) {
+	tokenABI, err := abi.JSON(strings.NewReader(viewABI))
+	require.NoError(t, err)
+	service := &balanceService{
+		tokenABI: tokenABI,
+		tokens:   make(map[common.Address]*big.Int),
+		eth:      make(map[common.Address]*big.Int),
+	}
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("eth", service))
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+	r := rollup.New(httpServer.URL, big.NewInt(77777), "test-rollup")
+	defer r.Close()
+	ac, err := NewRollupAccount(testPrivateKeyHex, r)
+	require.NoError(t, err)
+	addr := ac.GetAddress()
+	service.tokens[addr], service.eth[addr] = big.NewInt(100), big.NewInt(1000)
+
+	snapshot, err := NewBalanceSnapshot(t.Context(), common.HexToAddress("0x01"), tokenABI, ac)
+	require.NoError(t, err)
+
+	service.mu.Lock()
+	service.tokens[addr] = big.NewInt(60)
+	service.eth[addr] = big.NewInt(979)
+	service.mu.Unlock()
+
+	deltas, err := snapshot.Diff(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, Delta{Token: big.NewInt(-40), Eth: big.NewInt(-21)}, deltas[addr])
+	snapshot.AssertDeltas(t, map[common.Address]Delta{addr: {Token: big.NewInt(-40), Eth: big.NewInt(-21)}})
+	snapshot.AssertDeltas(t, map[common.Address]Delta{addr: {Token: big.NewInt(-40)}})
+
+	_, err = NewBalanceSnapshot(t.Context(), common.HexToAddress("0x01"), tokenABI, ac, ac)
+	require.ErrorContains(t, err, "appears more than once")
+}
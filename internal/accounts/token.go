@@ -0,0 +1,30 @@
+package accounts
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// TokenMetadata holds the descriptive ERC-20 views of a token
+type TokenMetadata struct {
+	Name     string
+	Symbol   string
+	Decimals uint8
+}
+
+// GetTokenMetadata reads the name, symbol and decimals of the ERC-20 token at token
+func (ac *Account) GetTokenMetadata(ctx context.Context, token common.Address, tokenABI abi.ABI) (TokenMetadata, error) {
+	var metadata TokenMetadata
+	if err := ac.CallContract(ctx, token, tokenABI, "name", &metadata.Name); err != nil {
+		return TokenMetadata{}, err
+	}
+	if err := ac.CallContract(ctx, token, tokenABI, "symbol", &metadata.Symbol); err != nil {
+		return TokenMetadata{}, err
+	}
+	if err := ac.CallContract(ctx, token, tokenABI, "decimals", &metadata.Decimals); err != nil {
+		return TokenMetadata{}, err
+	}
+	return metadata, nil
+}
@@ -0,0 +1,52 @@
+package accounts
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/compose-network/dome/internal/rollup"
+	"github.com/compose-network/dome/internal/rpctest"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const metadataABI = `[
+	{"type":"function","name":"name","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"string"}]},
+	{"type":"function","name":"symbol","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"string"}]},
+	{"type":"function","name":"decimals","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint8"}]}
+]`
+
+func TestGetTokenMetadata(t *testing.T) {
+	tokenABI, err := abi.JSON(strings.NewReader(metadataABI))
+	require.NoError(t, err)
+	results := make(map[string]string)
+	for name, value := range map[string]interface{}{"name": "Bridgeable Token", "symbol": "BRT", "decimals": uint8(6)} {
+		method := tokenABI.Methods[name]
+		data, err := method.Outputs.Pack(value)
+		require.NoError(t, err)
+		results[hexutil.Encode(method.ID)] = hexutil.Encode(data)
+	}
+
+	server := rpctest.NewServer(t, func(req rpctest.Request) interface{} {
+		if req.Method != "eth_call" {
+			return rpctest.ChainID
+		}
+		var call struct {
+			Input hexutil.Bytes `json:"input"`
+		}
+		assert.NoError(t, json.Unmarshal(req.Params[0], &call))
+		return results[hexutil.Encode(call.Input[:4])]
+	})
+
+	ac, err := NewRollupAccount(testPrivateKeyHex, rollup.New(server.URL, big.NewInt(77777), "test-rollup"))
+	require.NoError(t, err)
+
+	metadata, err := ac.GetTokenMetadata(t.Context(), common.HexToAddress("0x01"), tokenABI)
+	require.NoError(t, err)
+	require.Equal(t, TokenMetadata{Name: "Bridgeable Token", Symbol: "BRT", Decimals: 6}, metadata)
+}
@@ -0,0 +1,25 @@
+package accounts
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// TypedDataHash returns the EIP-712 digest keccak256("\x19\x01" || domainSeparator || structHash)
+func TypedDataHash(domainSeparator, structHash common.Hash) common.Hash {
+	return crypto.Keccak256Hash([]byte("\x19\x01"), domainSeparator.Bytes(), structHash.Bytes())
+}
+
+// SignTypedData signs the EIP-712 digest of domainSeparator and structHash with the account's key.
+// The returned 65-byte signature uses a 27/28 recovery id, as expected by Solidity's ecrecover.
+func (ac *Account) SignTypedData(domainSeparator, structHash common.Hash) ([]byte, error) {
+	digest := TypedDataHash(domainSeparator, structHash)
+	sig, err := crypto.Sign(digest.Bytes(), ac.privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign typed data: %w", err)
+	}
+	sig[crypto.RecoveryIDOffset] += 27
+	return sig, nil
+}
@@ -0,0 +1,36 @@
+package accounts
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/compose-network/dome/internal/rollup"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// The Mail example from EIP-712, signed with keccak256("cow")
+func TestSignTypedData(t *testing.T) {
+	domainSeparator := common.HexToHash("0xf2cee375fa42b42143804025fc449deafd50cc031ca257e0b194a650a912090f")
+	structHash := common.HexToHash("0xc52c0ee5d84264471806290a3f2c4cecfc5490626bf912d01f240d7a274b371e")
+	require.Equal(t, common.HexToHash("0xbe609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2"), TypedDataHash(domainSeparator, structHash))
+
+	key := crypto.Keccak256([]byte("cow"))
+	ac, err := NewRollupAccount(hexutil.Encode(key)[2:], rollup.New("http://127.0.0.1:0", big.NewInt(77777), "test-rollup"))
+	require.NoError(t, err)
+	defer ac.Close()
+	require.Equal(t, common.HexToAddress("0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"), ac.GetAddress())
+
+	sig, err := ac.SignTypedData(domainSeparator, structHash)
+	require.NoError(t, err)
+	require.Equal(t,
+		"0x4355c47d63924e8a72e509b65029052eb6c299d53a04e167c5775fd466751c9d"+
+			"07299936d304c153f6443dfa05f40ff007d72911b6f72307f996231605b91562"+"1c",
+		hexutil.Encode(sig))
+
+	signer, err := RecoverSigner(TypedDataHash(domainSeparator, structHash), sig)
+	require.NoError(t, err)
+	require.Equal(t, ac.GetAddress(), signer)
+}
@@ -0,0 +1,47 @@
+package accounts
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/compose-network/dome/internal/logger"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// balancePollInterval is how often WaitForTokenBalance re-reads the balance
+const balancePollInterval = 600 * time.Millisecond
+
+// WaitForTokenBalance polls the account's token balance until it equals expected or timeout elapses.
+// On timeout the returned error includes the last balance seen.
+func WaitForTokenBalance(ctx context.Context, account *Account, token common.Address, tokenABI abi.ABI, expected *big.Int, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var (
+		lastBalance *big.Int
+		lastErr     error
+	)
+	for {
+		balance, err := account.GetTokensBalance(ctx, token, tokenABI)
+		if err == nil && balance.Cmp(expected) == 0 {
+			return nil
+		}
+		if err == nil {
+			lastBalance = balance
+		}
+		lastErr = err
+		logger.Debug("Token balance of %s on %s is %v, waiting for %s...", account.GetAddress().Hex(), account.onRollup.Name(), balance, expected)
+
+		select {
+		case <-ctx.Done():
+			if lastErr != nil {
+				return fmt.Errorf("token balance of %s on %s did not reach %s within %s (last seen: %v, last error: %w)", account.GetAddress().Hex(), account.onRollup.Name(), expected, timeout, lastBalance, lastErr)
+			}
+			return fmt.Errorf("token balance of %s on %s did not reach %s within %s (last seen: %v)", account.GetAddress().Hex(), account.onRollup.Name(), expected, timeout, lastBalance)
+		case <-time.After(balancePollInterval):
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/compose-network/dome/internal/rollup"
+	"github.com/compose-network/dome/internal/transactions"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+/*
+RequireTxSuccess waits for tx on r and fails the test if it reverted.
+The failure message names the tx and includes its revert reason and gas usage.
+*/
+func RequireTxSuccess(t *testing.T, ctx context.Context, tx *types.Transaction, r *rollup.Rollup) *types.Receipt {
+	t.Helper()
+	_, receipt, err := transactions.GetTransactionDetails(ctx, tx.Hash(), r)
+	require.NoError(t, err, "tx %s on %s", tx.Hash().Hex(), r.Name())
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		require.Fail(t, fmt.Sprintf("tx %s on %s reverted", tx.Hash().Hex(), r.Name()), describeReceipt(ctx, tx, receipt, r))
+	}
+	return receipt
+}
+
+/*
+RequireTxFailed waits for tx on r and fails the test unless it was mined and reverted.
+*/
+func RequireTxFailed(t *testing.T, ctx context.Context, tx *types.Transaction, r *rollup.Rollup) *types.Receipt {
+	t.Helper()
+	_, receipt, err := transactions.GetTransactionDetails(ctx, tx.Hash(), r)
+	require.NoError(t, err, "tx %s on %s", tx.Hash().Hex(), r.Name())
+	if receipt.Status == types.ReceiptStatusSuccessful {
+		require.Fail(t, fmt.Sprintf("tx %s on %s succeeded but was expected to revert", tx.Hash().Hex(), r.Name()), describeReceipt(ctx, tx, receipt, r))
+	}
+	return receipt
+}
+
+// describeReceipt summarizes how tx executed, including its revert reason if it reverted
+func describeReceipt(ctx context.Context, tx *types.Transaction, receipt *types.Receipt, r *rollup.Rollup) string {
+	description := fmt.Sprintf("block %v, gas used %d of %d", receipt.BlockNumber, receipt.GasUsed, tx.Gas())
+	if receipt.Status == types.ReceiptStatusSuccessful {
+		return description
+	}
+	reason, err := transactions.GetReceiptRevertReason(ctx, tx, r, receipt)
+	if err != nil {
+		return fmt.Sprintf("%s, revert reason unavailable: %v", description, err)
+	}
+	return fmt.Sprintf("%s, revert reason: %q", description, reason)
+}
@@ -0,0 +1,105 @@
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/compose-network/dome/configs"
+	"github.com/compose-network/dome/internal/rollup"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// BridgeEvent is a decoded event emitted by the bridge contract.
+// Fields whose argument is absent from the event are left zero.
+type BridgeEvent struct {
+	// Name is the event name in the bridge ABI, e.g. the send or receive event
+	Name        string
+	SessionID   *big.Int
+	Sender      common.Address
+	Receiver    common.Address
+	Amount      *big.Int
+	BlockNumber uint64
+	LogIndex    uint
+	TxHash      common.Hash
+}
+
+/*
+ScanBridgeEvents returns every event of bridgeABI emitted by the configured bridge contract on rollup
+between fromBlock and toBlock inclusive, sorted by block and log index.
+Session IDs, senders, receivers and amounts are read from the sessionId, sender, receiver and amount arguments.
+*/
+func ScanBridgeEvents(ctx context.Context, rollup *rollup.Rollup, bridgeABI abi.ABI, fromBlock, toBlock uint64) ([]BridgeEvent, error) {
+	client, err := rollup.Client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	bridgeAddr := configs.Values.L2.Contracts[configs.ContractNameBridge].Address
+	logs, err := client.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(fromBlock),
+		ToBlock:   new(big.Int).SetUint64(toBlock),
+		Addresses: []common.Address{bridgeAddr},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter bridge logs on %s: %w", rollup.Name(), err)
+	}
+
+	events := make([]BridgeEvent, 0, len(logs))
+	for _, log := range logs {
+		event, ok, err := decodeBridgeEvent(bridgeABI, log)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			events = append(events, event)
+		}
+	}
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].BlockNumber != events[j].BlockNumber {
+			return events[i].BlockNumber < events[j].BlockNumber
+		}
+		return events[i].LogIndex < events[j].LogIndex
+	})
+	return events, nil
+}
+
+// decodeBridgeEvent decodes log into a BridgeEvent, reporting false for logs of events missing from bridgeABI
+func decodeBridgeEvent(bridgeABI abi.ABI, log types.Log) (BridgeEvent, bool, error) {
+	if len(log.Topics) == 0 {
+		return BridgeEvent{}, false, nil
+	}
+	event, err := bridgeABI.EventByID(log.Topics[0])
+	if err != nil {
+		return BridgeEvent{}, false, nil
+	}
+
+	var indexed abi.Arguments
+	for _, arg := range event.Inputs {
+		if arg.Indexed {
+			indexed = append(indexed, arg)
+		}
+	}
+	fields := make(map[string]interface{})
+	if err := bridgeABI.UnpackIntoMap(fields, event.Name, log.Data); err != nil {
+		return BridgeEvent{}, false, fmt.Errorf("failed to unpack %s data in tx %s: %w", event.Name, log.TxHash.Hex(), err)
+	}
+	if err := abi.ParseTopicsIntoMap(fields, indexed, log.Topics[1:]); err != nil {
+		return BridgeEvent{}, false, fmt.Errorf("failed to parse %s topics in tx %s: %w", event.Name, log.TxHash.Hex(), err)
+	}
+
+	decoded := BridgeEvent{
+		Name:        event.Name,
+		BlockNumber: log.BlockNumber,
+		LogIndex:    log.Index,
+		TxHash:      log.TxHash,
+	}
+	decoded.SessionID, _ = fields["sessionId"].(*big.Int)
+	decoded.Sender, _ = fields["sender"].(common.Address)
+	decoded.Receiver, _ = fields["receiver"].(common.Address)
+	decoded.Amount, _ = fields["amount"].(*big.Int)
+	return decoded, true, nil
+}
@@ -0,0 +1,82 @@
+package helpers
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/compose-network/dome/configs"
+	"github.com/compose-network/dome/internal/rollup"
+	"github.com/compose-network/dome/internal/rpctest"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const bridgeEventsABI = `[
+	{"anonymous":false,"type":"event","name":"Send","inputs":[
+		{"indexed":true,"name":"sessionId","type":"uint256"},
+		{"indexed":false,"name":"sender","type":"address"},
+		{"indexed":false,"name":"receiver","type":"address"},
+		{"indexed":false,"name":"amount","type":"uint256"}]},
+	{"anonymous":false,"type":"event","name":"Receive","inputs":[
+		{"indexed":true,"name":"sessionId","type":"uint256"},
+		{"indexed":false,"name":"receiver","type":"address"},
+		{"indexed":false,"name":"amount","type":"uint256"}]}
+]`
+
+func TestScanBridgeEvents(t *testing.T) {
+	bridgeABI, err := abi.JSON(strings.NewReader(bridgeEventsABI))
+	require.NoError(t, err)
+	bridgeAddr := configs.Values.L2.Contracts[configs.ContractNameBridge].Address
+	sender := common.HexToAddress("0x0a")
+	receiver := common.HexToAddress("0x0b")
+
+	sendData, err := bridgeABI.Events["Send"].Inputs.NonIndexed().Pack(sender, receiver, big.NewInt(5))
+	require.NoError(t, err)
+	receiveData, err := bridgeABI.Events["Receive"].Inputs.NonIndexed().Pack(receiver, big.NewInt(5))
+	require.NoError(t, err)
+	session := common.BigToHash(big.NewInt(7))
+	// returned out of order, with a log of an event the ABI does not know
+	logs := []types.Log{
+		{Address: bridgeAddr, Topics: []common.Hash{bridgeABI.Events["Receive"].ID, session}, Data: receiveData, BlockNumber: 12, Index: 0},
+		{Address: bridgeAddr, Topics: []common.Hash{common.HexToHash("0xdead")}, BlockNumber: 11, Index: 2},
+		{Address: bridgeAddr, Topics: []common.Hash{bridgeABI.Events["Send"].ID, session}, Data: sendData, BlockNumber: 11, Index: 1},
+	}
+
+	server := rpctest.NewServer(t, func(req rpctest.Request) interface{} {
+		if req.Method != "eth_getLogs" {
+			return rpctest.ChainID
+		}
+		var query struct {
+			FromBlock string           `json:"fromBlock"`
+			ToBlock   string           `json:"toBlock"`
+			Address   []common.Address `json:"address"`
+		}
+		assert.NoError(t, json.Unmarshal(req.Params[0], &query))
+		assert.Equal(t, "0xa", query.FromBlock)
+		assert.Equal(t, "0xc", query.ToBlock)
+		assert.Equal(t, []common.Address{bridgeAddr}, query.Address)
+		return logs
+	})
+	r := rollup.New(server.URL, big.NewInt(77777), "rollup-a")
+	t.Cleanup(r.Close)
+
+	events, err := ScanBridgeEvents(t.Context(), r, bridgeABI, 10, 12)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	require.Equal(t, "Send", events[0].Name)
+	require.Equal(t, big.NewInt(7), events[0].SessionID)
+	require.Equal(t, sender, events[0].Sender)
+	require.Equal(t, receiver, events[0].Receiver)
+	require.Equal(t, big.NewInt(5), events[0].Amount)
+
+	require.Equal(t, "Receive", events[1].Name)
+	require.Equal(t, big.NewInt(7), events[1].SessionID)
+	require.Equal(t, common.Address{}, events[1].Sender)
+	require.Equal(t, uint64(12), events[1].BlockNumber)
+}
@@ -7,16 +7,17 @@ import (
 
 	"github.com/compose-network/dome/internal/logger"
 	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/stretchr/testify/require"
 
-	"github.com/compose-network/dome/configs"
 	"github.com/compose-network/dome/internal/accounts"
 	"github.com/compose-network/dome/internal/transactions"
 )
 
 /*
-SendBridgeTx sends a bridge transaction from ac1 to ac2 with the given amount
+SendBridgeTx sends a bridge transaction from ac1 to ac2 with the given amount.
+It returns both signed transactions and their raw encodings.
 */
 func SendBridgeTx(
 	t *testing.T,
@@ -25,82 +26,39 @@ func SendBridgeTx(
 	amount *big.Int,
 	tokenABI abi.ABI,
 	bridgeABI abi.ABI,
-) (*types.Transaction, *types.Transaction, error) {
-
-	bridgeAddr := configs.Values.L2.Contracts[configs.ContractNameBridge].Address
-
+) (*types.Transaction, *types.Transaction, []byte, []byte, error) {
 	// generate random session ID , will be used for both transactions
-	sessionID := transactions.GenerateRandomSessionID()
-
-	// construct contract call parameters for transaction from accountA
-	calldataA, err := bridgeABI.Pack("send",
-		ac2.GetRollup().ChainID(),                                      // otherChainId
-		configs.Values.L2.Contracts[configs.ContractNameToken].Address, // token
-		ac1.GetAddress(),                                               // sender
-		ac2.GetAddress(),                                               // receiver
-		amount,                                                         // amount
-		sessionID,                                                      // sessionId
-		bridgeAddr,                                                     // destBridge
-	)
-	require.NoError(t, err)
-	require.NotNil(t, calldataA)
-
-	// Create transaction details
-	transactionADetails := transactions.TransactionDetails{
-		To:        bridgeAddr,
-		Value:     big.NewInt(0),
-		Gas:       900000,
-		GasTipCap: big.NewInt(1000000000),
-		GasFeeCap: big.NewInt(20000000000),
-		Data:      calldataA,
-	}
-
-	// create transaction to be sent from accountA
-	txA, signedTransactionA, err := transactions.CreateTransaction(t.Context(), transactionADetails, ac1)
-	require.NoError(t, err)
-	require.NotNil(t, signedTransactionA)
-	// preparations for tx A done -------------------------------------------------------------
-
-	// construct contract call parameters for transaction from accountB
-	calldataB, err := bridgeABI.Pack("receiveTokens",
-		ac1.GetRollup().ChainID(), // ChainSrc
-		ac2.GetAddress(),          // sender
-		ac2.GetAddress(),          // receiver
-		sessionID,                 // sessionId
-		bridgeAddr,                // srcBridge
-	)
+	return SendBridgeTxWithSessionID(t, ac1, ac2, amount, transactions.GenerateRandomSessionID(), tokenABI, bridgeABI)
+}
+
+/*
+SendBridgeTxWithSessionID sends a bridge transaction from ac1 to ac2 with the given amount under the given session ID
+*/
+func SendBridgeTxWithSessionID(
+	t *testing.T,
+	ac1 *accounts.Account,
+	ac2 *accounts.Account,
+	amount *big.Int,
+	sessionID *big.Int,
+	tokenABI abi.ABI,
+	bridgeABI abi.ABI,
+) (*types.Transaction, *types.Transaction, []byte, []byte, error) {
+	txA, txB, crossTxRequestMsg, err := transactions.BuildBridgeCrossTx(t.Context(), ac1, ac2, amount, tokenABI, bridgeABI,
+		transactions.WithSessionID(sessionID))
 	require.NoError(t, err)
-	require.NotNil(t, calldataB)
-
-	// Create transaction details
-	transactionBDetails := transactions.TransactionDetails{
-		To:        bridgeAddr,
-		Value:     big.NewInt(0),
-		Gas:       900000,
-		GasTipCap: big.NewInt(1000000000),
-		GasFeeCap: big.NewInt(20000000000),
-		Data:      calldataB,
-	}
-
-	// create transaction to be sent from accountB
-	txB, signedTransactionB, err := transactions.CreateTransaction(t.Context(), transactionBDetails, ac2)
+	signedTransactionA, err := transactions.RawBytes(txA)
 	require.NoError(t, err)
-	require.NotNil(t, signedTransactionB)
-	// preparations for tx B done -------------------------------------------------------------
-
-	// create cross tx request msg
-	crossTxRequestMsg, err := transactions.CreateCrossTxRequestMsg(t.Context(), ac1, ac2, signedTransactionA, signedTransactionB)
+	signedTransactionB, err := transactions.RawBytes(txB)
 	require.NoError(t, err)
-	require.NotNil(t, crossTxRequestMsg)
 
 	// send cross tx request msg to source chain (A)
-	err = transactions.SendCrossTxRequestMsg(t.Context(), ac1.GetRollup().RPCURL(), crossTxRequestMsg)
+	_, err = transactions.SendCrossTxToCoordinator(t.Context(), ac1.GetRollup().RPCURL(), crossTxRequestMsg)
 	require.NoError(t, err)
 
 	logger.Info("Bridge transaction A sent successfully: %s", txA.Hash())
 	logger.Info("Bridge transaction B sent successfully: %s", txB.Hash())
 
-	return txA, txB, err
+	return txA, txB, signedTransactionA, signedTransactionB, err
 }
 
 /*
@@ -117,80 +75,50 @@ func SendBridgeTxWithNonce(
 	tokenABI abi.ABI,
 	bridgeABI abi.ABI,
 
-) (*types.Transaction, *types.Transaction, error) {
-
-	bridgeAddr := configs.Values.L2.Contracts[configs.ContractNameBridge].Address
-
-	// generate random session ID , will be used for both transactions
-	sessionID := transactions.GenerateRandomSessionID()
-
-	// construct contract call parameters for transaction from accountA
-	calldataA, err := bridgeABI.Pack("send",
-		ac2.GetRollup().ChainID(),                                      // otherChainId
-		configs.Values.L2.Contracts[configs.ContractNameToken].Address, // token
-		ac1.GetAddress(),                                               // sender
-		ac2.GetAddress(),                                               // receiver
-		amount,                                                         // amount
-		sessionID,                                                      // sessionId
-		bridgeAddr,                                                     // destBridge
-	)
-	require.NoError(t, err)
-	require.NotNil(t, calldataA)
-
-	// Create transaction details
-	transactionADetails := transactions.TransactionDetails{
-		To:        bridgeAddr,
-		Value:     big.NewInt(0),
-		Gas:       900000,
-		GasTipCap: big.NewInt(1000000000),
-		GasFeeCap: big.NewInt(20000000000),
-		Data:      calldataA,
-	}
-
-	// create transaction to be sent from accountA
-	txA, signedTransactionA, err := transactions.CreateTransactionWithNonce(context.Background(), transactionADetails, ac1, ac1_nonce)
-	require.NoError(t, err)
-	require.NotNil(t, signedTransactionA)
-	// preparations for tx A done -------------------------------------------------------------
-
-	// construct contract call parameters for transaction from accountB
-	calldataB, err := bridgeABI.Pack("receiveTokens",
-		ac1.GetRollup().ChainID(), // ChainSrc
-		ac2.GetAddress(),          // sender
-		ac2.GetAddress(),          // receiver
-		sessionID,                 // sessionId
-		bridgeAddr,                // srcBridge
-	)
-	require.NoError(t, err)
-	require.NotNil(t, calldataB)
-
-	// Create transaction details
-	transactionBDetails := transactions.TransactionDetails{
-		To:        bridgeAddr,
-		Value:     big.NewInt(0),
-		Gas:       900000,
-		GasTipCap: big.NewInt(1000000000),
-		GasFeeCap: big.NewInt(20000000000),
-		Data:      calldataB,
-	}
-
-	// create transaction to be sent from accountB
-	txB, signedTransactionB, err := transactions.CreateTransactionWithNonce(context.Background(), transactionBDetails, ac2, ac2_nonce)
-	require.NoError(t, err)
-	require.NotNil(t, signedTransactionB)
-	// preparations for tx B done -------------------------------------------------------------
-
-	// create cross tx request msg
-	crossTxRequestMsg, err := transactions.CreateCrossTxRequestMsg(context.Background(), ac1, ac2, signedTransactionA, signedTransactionB)
-	require.NoError(t, err)
-	require.NotNil(t, crossTxRequestMsg)
+) (*types.Transaction, *types.Transaction, []byte, []byte, error) {
+	txA, txB, rawA, rawB, crossTxRequestMsg := CreateBridgeTxWithNonce(t, ac1, ac1_nonce, ac2, ac2_nonce, amount, tokenABI, bridgeABI)
 
 	// send cross tx request msg to source chain (A)
-	err = transactions.SendCrossTxRequestMsg(context.Background(), ac1.GetRollup().RPCURL(), crossTxRequestMsg)
+	_, err := transactions.SendCrossTxToCoordinator(context.Background(), ac1.GetRollup().RPCURL(), crossTxRequestMsg)
 	require.NoError(t, err)
 
 	logger.Info("Bridge transaction A sent successfully: %s", txA.Hash())
 	logger.Info("Bridge transaction B sent successfully: %s", txB.Hash())
 
-	return txA, txB, err
+	return txA, txB, rawA, rawB, err
+}
+
+/*
+CreateBridgeTxWithNonce builds the same bridge transactions as SendBridgeTxWithNonce and returns them, their raw
+encodings and the encoded cross tx request, without sending it. Use it to submit many requests at once with SendCrossTxBatch.
+*/
+func CreateBridgeTxWithNonce(
+	t *testing.T,
+	ac1 *accounts.Account,
+	ac1_nonce uint64,
+	ac2 *accounts.Account,
+	ac2_nonce uint64,
+	amount *big.Int,
+	tokenABI abi.ABI,
+	bridgeABI abi.ABI,
+) (*types.Transaction, *types.Transaction, []byte, []byte, []byte) {
+	txA, txB, crossTxRequestMsg, err := transactions.BuildBridgeCrossTx(context.Background(), ac1, ac2, amount, tokenABI, bridgeABI,
+		transactions.WithNonces(ac1_nonce, ac2_nonce))
+	require.NoError(t, err)
+	signedTransactionA, err := transactions.RawBytes(txA)
+	require.NoError(t, err)
+	signedTransactionB, err := transactions.RawBytes(txB)
+	require.NoError(t, err)
+
+	return txA, txB, signedTransactionA, signedTransactionB, crossTxRequestMsg
+}
+
+/*
+BuildReceiveLegFromSend decodes bridge send calldata and packs the matching receiveTokens calldata, so both legs always
+agree on sender, receiver and session ID. The send call only names the destination chain and bridge, so the source
+chain ID and the source bridge (the send tx's To) must be passed as srcChainID and srcBridge.
+BuildBridgeCrossTx derives its receive leg the same way.
+*/
+func BuildReceiveLegFromSend(sendCalldata []byte, srcChainID *big.Int, srcBridge common.Address, bridgeABI abi.ABI) ([]byte, error) {
+	return transactions.ReceiveCalldataFromSend(sendCalldata, srcChainID, srcBridge, bridgeABI)
 }
@@ -0,0 +1,48 @@
+package helpers
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+const bridgeCallsABI = `[
+	{"type":"function","name":"send","stateMutability":"nonpayable","outputs":[],"inputs":[
+		{"name":"otherChainId","type":"uint256"},{"name":"token","type":"address"},{"name":"sender","type":"address"},
+		{"name":"receiver","type":"address"},{"name":"amount","type":"uint256"},{"name":"sessionId","type":"uint256"},
+		{"name":"destBridge","type":"address"}]},
+	{"type":"function","name":"receiveTokens","stateMutability":"nonpayable","outputs":[],"inputs":[
+		{"name":"chainSrc","type":"uint256"},{"name":"sender","type":"address"},{"name":"receiver","type":"address"},
+		{"name":"sessionId","type":"uint256"},{"name":"srcBridge","type":"address"}]}
+]`
+
+func TestBuildReceiveLegFromSend(t *testing.T) {
+	bridgeABI, err := abi.JSON(strings.NewReader(bridgeCallsABI))
+	require.NoError(t, err)
+	sender := common.HexToAddress("0x00000000000000000000000000000000000000a1")
+	receiver := common.HexToAddress("0x00000000000000000000000000000000000000b2")
+	srcBridge := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	destBridge := common.HexToAddress("0x4444444444444444444444444444444444444444")
+	sessionID := big.NewInt(4242)
+
+	sendCalldata, err := bridgeABI.Pack("send", big.NewInt(88888), common.HexToAddress("0x3333"), sender, receiver, big.NewInt(100), sessionID, destBridge)
+	require.NoError(t, err)
+
+	receiveCalldata, err := BuildReceiveLegFromSend(sendCalldata, big.NewInt(77777), srcBridge, bridgeABI)
+	require.NoError(t, err)
+	require.Equal(t, bridgeABI.Methods["receiveTokens"].ID, receiveCalldata[:4])
+	receive, err := bridgeABI.Methods["receiveTokens"].Inputs.Unpack(receiveCalldata[4:])
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(77777), receive[0], "chainSrc")
+	require.Equal(t, sender, receive[1], "sender")
+	require.Equal(t, receiver, receive[2], "receiver")
+	require.Equal(t, sessionID, receive[3], "sessionId")
+	require.Equal(t, srcBridge, receive[4], "srcBridge is the send leg's bridge, not its destBridge")
+
+	_, err = BuildReceiveLegFromSend(receiveCalldata, big.NewInt(77777), srcBridge, bridgeABI)
+	require.ErrorContains(t, err, "not a bridge send call")
+}
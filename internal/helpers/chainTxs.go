@@ -3,6 +3,7 @@ package helpers
 import (
 	"context"
 	"fmt"
+	"github.com/compose-network/dome/configs"
 	"github.com/compose-network/dome/internal/accounts"
 	"github.com/compose-network/dome/internal/logger"
 	"github.com/compose-network/dome/internal/transactions"
@@ -12,20 +13,17 @@ import (
 )
 
 func SendSelfMoveBalanceTx(ctx context.Context, ac *accounts.Account, amount *big.Int) (*types.Transaction, common.Hash, error) {
-	txDetails := transactions.TransactionDetails{
-		To:        ac.GetAddress(),
-		Value:     amount,
-		Gas:       25000,
-		GasTipCap: big.NewInt(1000000),
-		GasFeeCap: big.NewInt(2000000),
-		Data:      nil,
-	}
+	txDetails := transactions.NewTxDetails(ac.GetAddress()).
+		Value(amount).
+		Gas(configs.Values.GasProfile.Gas(configs.GasOpEthTransfer)).
+		Fees(big.NewInt(1000000), big.NewInt(2000000)).
+		Build()
 
 	tx, _, err := transactions.CreateTransaction(ctx, txDetails, ac)
 	if err != nil {
 		return nil, common.Hash{}, fmt.Errorf("failed to create transaction: %w", err)
 	}
-	hash, err := transactions.SendTransaction(ctx, tx, ac.GetRollup().RPCURL())
+	hash, err := transactions.SendTransaction(ctx, tx, ac.GetRollup())
 	if err != nil {
 		return nil, common.Hash{}, fmt.Errorf("failed to send transaction: %w", err)
 	}
@@ -34,20 +32,17 @@ func SendSelfMoveBalanceTx(ctx context.Context, ac *accounts.Account, amount *bi
 }
 
 func SendSelfMoveBalanceTxWithNonce(ctx context.Context, ac *accounts.Account, nonce uint64, amount *big.Int) (*types.Transaction, common.Hash, error) {
-	txDetails := transactions.TransactionDetails{
-		To:        ac.GetAddress(),
-		Value:     amount,
-		Gas:       25000,
-		GasTipCap: big.NewInt(1000000),
-		GasFeeCap: big.NewInt(2000000),
-		Data:      nil,
-	}
+	txDetails := transactions.NewTxDetails(ac.GetAddress()).
+		Value(amount).
+		Gas(configs.Values.GasProfile.Gas(configs.GasOpEthTransfer)).
+		Fees(big.NewInt(1000000), big.NewInt(2000000)).
+		Build()
 
 	tx, _, err := transactions.CreateTransactionWithNonce(ctx, txDetails, ac, nonce)
 	if err != nil {
 		return nil, common.Hash{}, fmt.Errorf("failed to create transaction: %w", err)
 	}
-	hash, err := transactions.SendTransaction(ctx, tx, ac.GetRollup().RPCURL())
+	hash, err := transactions.SendTransaction(ctx, tx, ac.GetRollup())
 	if err != nil {
 		return nil, common.Hash{}, fmt.Errorf("failed to send transaction: %w", err)
 	}
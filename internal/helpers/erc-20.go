@@ -2,17 +2,19 @@ package helpers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
+	"strings"
 	"testing"
 
+	"github.com/compose-network/dome/configs"
 	"github.com/compose-network/dome/internal/logger"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/stretchr/testify/require"
 
-	"github.com/compose-network/dome/configs"
 	"github.com/compose-network/dome/internal/accounts"
 	"github.com/compose-network/dome/internal/transactions"
 )
@@ -21,35 +23,10 @@ import (
 MintTokens mints tokens to the given account
 */
 func SendMintTx(t *testing.T, ac *accounts.Account, amount *big.Int, tokenABI abi.ABI) (*types.Transaction, common.Hash, error) {
-	tokenAddress := configs.Values.L2.Contracts[configs.ContractNameToken].Address
-
-	calldata, err := tokenABI.Pack("mint",
-		ac.GetAddress(),
-		amount,
-	)
-	require.NoError(t, err)
-	require.NotNil(t, calldata)
-
-	transactionDetails := transactions.TransactionDetails{
-		To:        tokenAddress,
-		Value:     big.NewInt(0),
-		Gas:       900000,
-		GasTipCap: big.NewInt(1000000000),
-		GasFeeCap: big.NewInt(20000000000),
-		Data:      calldata,
-	}
-
-	tx, signedTransaction, err := transactions.CreateTransaction(t.Context(), transactionDetails, ac)
-	require.NoError(t, err)
-	require.NotNil(t, signedTransaction)
-	hash, err := transactions.SendTransaction(t.Context(), tx, ac.GetRollup().RPCURL())
-	logger.Info("Mint transaction sent successfully: %s", hash)
-	require.NoError(t, err)
-	_, receipt, err := transactions.GetTransactionDetails(t.Context(), hash, ac.GetRollup())
+	tx, receipt, err := transactions.Mint(t.Context(), ac, amount, tokenABI)
 	require.NoError(t, err)
 	require.NotNil(t, receipt)
-	require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
-	return tx, hash, nil
+	return tx, tx.Hash(), nil
 }
 
 /*
@@ -62,39 +39,25 @@ func ApproveTokens(
 	spender common.Address,
 	tokenABI abi.ABI,
 ) (*types.Transaction, common.Hash, error) {
-	logger.Info("Approving tokens on rollup %s for %s on %s ...", ac.GetRollup().Name(), ac.GetAddress().Hex(), spender.Hex())
-	tokenAddress := configs.Values.L2.Contracts[configs.ContractNameToken].Address
-	// set amount to max uint256 (2^256 - 1)
-	maxUint256 := new(big.Int).Exp(big.NewInt(2), big.NewInt(256), nil)
-	amount := new(big.Int).Sub(maxUint256, big.NewInt(1))
-
-	calldata, err := tokenABI.Pack("approve",
-		spender,
-		amount,
-	)
-	require.NoError(t, err)
-	require.NotNil(t, calldata)
-
-	transactionDetails := transactions.TransactionDetails{
-		To:        tokenAddress,
-		Value:     big.NewInt(0),
-		Gas:       900000,
-		GasTipCap: big.NewInt(1000000000),
-		GasFeeCap: big.NewInt(20000000000),
-		Data:      calldata,
-	}
+	return ApproveTokensAmount(t, ac, spender, maxUint256(), tokenABI)
+}
 
-	tx, signedTransaction, err := transactions.CreateTransaction(t.Context(), transactionDetails, ac)
-	require.NoError(t, err)
-	require.NotNil(t, signedTransaction)
-	hash, err := transactions.SendTransaction(t.Context(), tx, ac.GetRollup().RPCURL())
-	require.NoError(t, err)
-	_, receipt, err := transactions.GetTransactionDetails(t.Context(), hash, ac.GetRollup())
+/*
+ApproveTokensAmount approves exactly amount of tokens to the spender.
+Use it to check how the spender's allowance is consumed.
+*/
+func ApproveTokensAmount(
+	t *testing.T,
+	ac *accounts.Account,
+	spender common.Address,
+	amount *big.Int,
+	tokenABI abi.ABI,
+) (*types.Transaction, common.Hash, error) {
+	logger.Info("Approving %s tokens on rollup %s for %s on %s ...", amount, ac.GetRollup().Name(), ac.GetAddress().Hex(), spender.Hex())
+	tx, receipt, err := transactions.Approve(t.Context(), ac, spender, amount, tokenABI)
 	require.NoError(t, err)
 	require.NotNil(t, receipt)
-	require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
-	logger.Info("Approve transaction executed successfully: %s", hash)
-	return tx, hash, nil
+	return tx, tx.Hash(), nil
 }
 
 /*
@@ -107,49 +70,105 @@ func DefaultApproveTokens(
 	spender common.Address,
 	tokenABI abi.ABI,
 ) (*types.Transaction, common.Hash, error) {
-	tokenAddress := configs.Values.L2.Contracts[configs.ContractNameToken].Address
-	// set amount to max uint256 (2^256 - 1)
-	maxUint256 := new(big.Int).Exp(big.NewInt(2), big.NewInt(256), nil)
-	amount := new(big.Int).Sub(maxUint256, big.NewInt(1))
-
-	calldata, err := tokenABI.Pack("approve",
-		spender,
-		amount,
-	)
+	tx, _, err := transactions.Approve(ctx, ac, spender, maxUint256(), tokenABI)
 	if err != nil {
 		return nil, common.Hash{}, err
 	}
-	if calldata == nil {
-		return nil, common.Hash{}, fmt.Errorf("calldata is nil")
+	return tx, tx.Hash(), nil
+}
+
+var (
+	// ErrInsufficientTokenBalance is returned by CheckBridgeSendPreconditions when the sender holds too few tokens
+	ErrInsufficientTokenBalance = errors.New("insufficient token balance")
+	// ErrInsufficientAllowance is returned by CheckBridgeSendPreconditions when the bridge may not spend enough tokens
+	ErrInsufficientAllowance = errors.New("insufficient bridge allowance")
+)
+
+/*
+CheckBridgeSendPreconditions verifies that ac holds at least amount of tokens and has approved the bridge for at least amount.
+It returns an error wrapping ErrInsufficientTokenBalance or ErrInsufficientAllowance naming the failed precondition,
+so tests can assert why a bridge send is expected to fail.
+*/
+func CheckBridgeSendPreconditions(ctx context.Context, ac *accounts.Account, amount *big.Int, tokenABI abi.ABI) error {
+	token := configs.Values.L2.Contracts[configs.ContractNameToken].Address
+	bridge := configs.Values.L2.Contracts[configs.ContractNameBridge].Address
+
+	balance, err := ac.GetTokensBalance(ctx, token, tokenABI)
+	if err != nil {
+		return err
+	}
+	if balance.Cmp(amount) < 0 {
+		return fmt.Errorf("%w: %s holds %s on %s, needs %s", ErrInsufficientTokenBalance, ac.GetAddress().Hex(), balance, ac.GetRollup().Name(), amount)
 	}
 
-	transactionDetails := transactions.TransactionDetails{
-		To:        tokenAddress,
-		Value:     big.NewInt(0),
-		Gas:       900000,
-		GasTipCap: big.NewInt(1000000000),
-		GasFeeCap: big.NewInt(20000000000),
-		Data:      calldata,
+	allowance, err := ac.GetTokenAllowance(ctx, token, bridge, tokenABI)
+	if err != nil {
+		return err
+	}
+	if allowance.Cmp(amount) < 0 {
+		return fmt.Errorf("%w: %s allows the bridge %s on %s, needs %s", ErrInsufficientAllowance, ac.GetAddress().Hex(), allowance, ac.GetRollup().Name(), amount)
 	}
+	return nil
+}
 
-	tx, signedTransaction, err := transactions.CreateTransaction(ctx, transactionDetails, ac)
+/*
+EnsureTokenBalance makes sure ac holds at least minimum of token, minting only the shortfall.
+Unlike SendMintTx it does nothing when the balance is already sufficient, so balance-dependent tests can be rerun
+without the balance drifting. It returns an error wrapping ErrInsufficientTokenBalance if tokenABI has no mint method
+or the balance is still below minimum after minting.
+*/
+func EnsureTokenBalance(ctx context.Context, ac *accounts.Account, token common.Address, minimum *big.Int, tokenABI abi.ABI) error {
+	balance, err := ac.GetTokensBalance(ctx, token, tokenABI)
 	if err != nil {
-		return nil, common.Hash{}, err
+		return err
+	}
+	if balance.Cmp(minimum) >= 0 {
+		logger.Debug("%s already holds %s tokens on %s, minimum is %s", ac.GetAddress().Hex(), balance, ac.GetRollup().Name(), minimum)
+		return nil
 	}
-	if signedTransaction == nil {
-		return nil, common.Hash{}, fmt.Errorf("signed transaction is nil")
+
+	shortfall := new(big.Int).Sub(minimum, balance)
+	if _, ok := tokenABI.Methods["mint"]; !ok {
+		return fmt.Errorf("%w: %s holds %s on %s, needs %s and token %s cannot mint", ErrInsufficientTokenBalance, ac.GetAddress().Hex(), balance, ac.GetRollup().Name(), minimum, token.Hex())
 	}
-	hash, err := transactions.SendTransaction(ctx, tx, ac.GetRollup().RPCURL())
+	calldata, err := tokenABI.Pack("mint", ac.GetAddress(), shortfall)
 	if err != nil {
-		return nil, common.Hash{}, err
+		return fmt.Errorf("failed to pack mint calldata: %w", err)
+	}
+
+	logger.Info("Minting %s tokens on rollup %s for %s to reach %s ...", shortfall, ac.GetRollup().Name(), ac.GetAddress().Hex(), minimum)
+	details := transactions.NewTxDetails(token).Gas(configs.Values.GasProfile.Gas(configs.GasOpMint)).Data(calldata).Build()
+	if _, _, err := transactions.SendAndWait(ctx, details, ac); err != nil {
+		return fmt.Errorf("mint failed: %w", err)
 	}
-	_, receipt, err := transactions.GetTransactionDetails(ctx, hash, ac.GetRollup())
+
+	balance, err = ac.GetTokensBalance(ctx, token, tokenABI)
 	if err != nil {
-		return nil, common.Hash{}, err
+		return err
+	}
+	if balance.Cmp(minimum) < 0 {
+		return fmt.Errorf("%w: %s holds %s on %s after minting, needs %s", ErrInsufficientTokenBalance, ac.GetAddress().Hex(), balance, ac.GetRollup().Name(), minimum)
 	}
-	if receipt.Status != types.ReceiptStatusSuccessful {
-		return nil, common.Hash{}, fmt.Errorf("approve transaction failed")
+	return nil
+}
+
+// ToTokenUnits formats a raw token amount with the given number of decimals, e.g. 1500000000000000000 with 18 decimals as "1.5"
+func ToTokenUnits(amount *big.Int, decimals uint8) string {
+	unit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	whole, frac := new(big.Int).QuoRem(new(big.Int).Abs(amount), unit, new(big.Int))
+
+	sign := ""
+	if amount.Sign() < 0 {
+		sign = "-"
 	}
-	logger.Info("Approve transaction executed successfully: %s", hash)
-	return tx, hash, err
+	if frac.Sign() == 0 {
+		return sign + whole.String()
+	}
+	fracDigits := strings.TrimRight(fmt.Sprintf("%0*s", decimals, frac.String()), "0")
+	return sign + whole.String() + "." + fracDigits
+}
+
+// maxUint256 returns 2^256 - 1
+func maxUint256() *big.Int {
+	return new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
 }
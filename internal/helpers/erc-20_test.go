@@ -0,0 +1,180 @@
+package helpers
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/compose-network/dome/configs"
+	"github.com/compose-network/dome/internal/accounts"
+	"github.com/compose-network/dome/internal/rollup"
+	"github.com/compose-network/dome/internal/rpctest"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToTokenUnits(t *testing.T) {
+	tests := []struct {
+		amount   string
+		decimals uint8
+		want     string
+	}{
+		{amount: "1500000000000000000", decimals: 18, want: "1.5"},
+		{amount: "1000000000000000000", decimals: 18, want: "1"},
+		{amount: "1", decimals: 18, want: "0.000000000000000001"},
+		{amount: "0", decimals: 18, want: "0"},
+		{amount: "-2500000", decimals: 6, want: "-2.5"},
+		{amount: "42", decimals: 0, want: "42"},
+	}
+	for _, tt := range tests {
+		amount, ok := new(big.Int).SetString(tt.amount, 10)
+		require.True(t, ok)
+		require.Equal(t, tt.want, ToTokenUnits(amount, tt.decimals), "%s with %d decimals", tt.amount, tt.decimals)
+	}
+}
+
+const balanceAndAllowanceABI = `[
+	{"type":"function","name":"balanceOf","stateMutability":"view","inputs":[{"name":"owner","type":"address"}],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"allowance","stateMutability":"view","inputs":[{"name":"owner","type":"address"},{"name":"spender","type":"address"}],"outputs":[{"name":"","type":"uint256"}]}
+]`
+
+// newTokenServer starts a JSON-RPC server for chain 77777 whose token reports balance and allowance for every account
+func newTokenServer(t *testing.T, tokenABI abi.ABI, balance, allowance int64) *httptest.Server {
+	t.Helper()
+	results := make(map[string]string)
+	for name, value := range map[string]int64{"balanceOf": balance, "allowance": allowance} {
+		data, err := tokenABI.Methods[name].Outputs.Pack(big.NewInt(value))
+		require.NoError(t, err)
+		results[hexutil.Encode(tokenABI.Methods[name].ID)] = hexutil.Encode(data)
+	}
+	return rpctest.NewServer(t, func(req rpctest.Request) interface{} {
+		if req.Method != "eth_call" {
+			return rpctest.ChainID
+		}
+		var call struct {
+			Input hexutil.Bytes `json:"input"`
+		}
+		assert.NoError(t, json.Unmarshal(req.Params[0], &call))
+		return results[hexutil.Encode(call.Input[:4])]
+	})
+}
+
+func TestCheckBridgeSendPreconditions(t *testing.T) {
+	tokenABI, err := abi.JSON(strings.NewReader(balanceAndAllowanceABI))
+	require.NoError(t, err)
+	newAccount := func(balance, allowance int64) *accounts.Account {
+		r := rollup.New(newTokenServer(t, tokenABI, balance, allowance).URL, big.NewInt(77777), "rollup-a")
+		t.Cleanup(r.Close)
+		ac, err := accounts.NewRollupAccount("4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318", r)
+		require.NoError(t, err)
+		return ac
+	}
+	amount := big.NewInt(100)
+
+	require.NoError(t, CheckBridgeSendPreconditions(t.Context(), newAccount(100, 100), amount, tokenABI))
+	require.ErrorIs(t, CheckBridgeSendPreconditions(t.Context(), newAccount(99, 100), amount, tokenABI), ErrInsufficientTokenBalance)
+	require.ErrorIs(t, CheckBridgeSendPreconditions(t.Context(), newAccount(100, 99), amount, tokenABI), ErrInsufficientAllowance)
+}
+
+const mintableTokenABI = `[
+	{"type":"function","name":"balanceOf","stateMutability":"view","inputs":[{"name":"owner","type":"address"}],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"mint","stateMutability":"nonpayable","inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[]}
+]`
+
+// mintableToken is a chain 77777 JSON-RPC server whose token applies every mint it receives to a single balance
+type mintableToken struct {
+	mu      sync.Mutex
+	balance *big.Int
+	mints   []*big.Int
+	chain   *rpctest.Chain
+}
+
+func newMintableTokenServer(t *testing.T, tokenABI abi.ABI, balance int64) (*mintableToken, *httptest.Server) {
+	t.Helper()
+	token := &mintableToken{balance: big.NewInt(balance), chain: rpctest.NewChain(t)}
+	server := rpctest.NewServer(t, func(req rpctest.Request) interface{} {
+		token.mu.Lock()
+		defer token.mu.Unlock()
+
+		switch req.Method {
+		case "eth_chainId":
+			return rpctest.ChainID
+		case "eth_getTransactionCount":
+			return hexutil.Uint64(len(token.mints))
+		case "eth_estimateGas":
+			return hexutil.Uint64(60000)
+		case "eth_call":
+			data, err := tokenABI.Methods["balanceOf"].Outputs.Pack(token.balance)
+			assert.NoError(t, err)
+			return hexutil.Encode(data)
+		case "eth_sendRawTransaction":
+			var raw hexutil.Bytes
+			assert.NoError(t, json.Unmarshal(req.Params[0], &raw))
+			var tx types.Transaction
+			assert.NoError(t, tx.UnmarshalBinary(raw))
+			args, err := tokenABI.Methods["mint"].Inputs.Unpack(tx.Data()[4:])
+			assert.NoError(t, err)
+			amount := args[1].(*big.Int)
+			token.mints = append(token.mints, amount)
+			token.balance = new(big.Int).Add(token.balance, amount)
+			token.chain.Mine(&tx, types.ReceiptStatusSuccessful, 1)
+			return tx.Hash()
+		}
+		if result, ok := token.chain.Handle(req); ok {
+			return result
+		}
+		t.Errorf("unexpected method %s", req.Method)
+		return nil
+	})
+	return token, server
+}
+
+func TestEnsureTokenBalance(t *testing.T) {
+	tokenABI, err := abi.JSON(strings.NewReader(mintableTokenABI))
+	require.NoError(t, err)
+	tokenAddress := common.HexToAddress("0x0000000000000000000000000000000000007070")
+	gasFees := configs.Values.GasFees
+	t.Cleanup(func() { configs.Values.GasFees = gasFees })
+	configs.Values.GasFees = configs.GasFees{TipCapGwei: 1, FeeCapGwei: 20}
+	newAccount := func(server *httptest.Server) *accounts.Account {
+		r := rollup.New(server.URL, big.NewInt(77777), "rollup-a")
+		t.Cleanup(r.Close)
+		ac, err := accounts.NewRollupAccount("4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318", r)
+		require.NoError(t, err)
+		return ac
+	}
+
+	t.Run("already enough", func(t *testing.T) {
+		token, server := newMintableTokenServer(t, tokenABI, 150)
+		require.NoError(t, EnsureTokenBalance(t.Context(), newAccount(server), tokenAddress, big.NewInt(100), tokenABI))
+		require.Empty(t, token.mints)
+		require.Equal(t, big.NewInt(150), token.balance)
+	})
+
+	t.Run("needs top-up", func(t *testing.T) {
+		token, server := newMintableTokenServer(t, tokenABI, 40)
+		ac := newAccount(server)
+		require.NoError(t, EnsureTokenBalance(t.Context(), ac, tokenAddress, big.NewInt(100), tokenABI))
+		require.Equal(t, []*big.Int{big.NewInt(60)}, token.mints)
+
+		// a rerun finds the minimum already met and mints nothing more
+		require.NoError(t, EnsureTokenBalance(t.Context(), ac, tokenAddress, big.NewInt(100), tokenABI))
+		require.Len(t, token.mints, 1)
+		require.Equal(t, big.NewInt(100), token.balance)
+	})
+
+	t.Run("cannot mint", func(t *testing.T) {
+		readOnlyABI, err := abi.JSON(strings.NewReader(balanceAndAllowanceABI))
+		require.NoError(t, err)
+		_, server := newMintableTokenServer(t, readOnlyABI, 40)
+		err = EnsureTokenBalance(t.Context(), newAccount(server), tokenAddress, big.NewInt(100), readOnlyABI)
+		require.ErrorIs(t, err, ErrInsufficientTokenBalance)
+	})
+}
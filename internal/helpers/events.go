@@ -0,0 +1,44 @@
+package helpers
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+/*
+DecodeEvents decodes every log in receipt emitted for eventName of contractABI.
+Each event is returned as a map of argument name to value, covering both indexed and non-indexed arguments.
+Receipts without matching logs yield an empty slice.
+*/
+func DecodeEvents(receipt *types.Receipt, contractABI abi.ABI, eventName string) ([]map[string]interface{}, error) {
+	event, ok := contractABI.Events[eventName]
+	if !ok {
+		return nil, fmt.Errorf("event %s not found in ABI", eventName)
+	}
+
+	var indexed abi.Arguments
+	for _, arg := range event.Inputs {
+		if arg.Indexed {
+			indexed = append(indexed, arg)
+		}
+	}
+
+	events := make([]map[string]interface{}, 0)
+	for _, log := range receipt.Logs {
+		if len(log.Topics) == 0 || log.Topics[0] != event.ID {
+			continue
+		}
+
+		fields := make(map[string]interface{})
+		if err := contractABI.UnpackIntoMap(fields, eventName, log.Data); err != nil {
+			return nil, fmt.Errorf("failed to unpack %s data in tx %s: %w", eventName, log.TxHash.Hex(), err)
+		}
+		if err := abi.ParseTopicsIntoMap(fields, indexed, log.Topics[1:]); err != nil {
+			return nil, fmt.Errorf("failed to parse %s topics in tx %s: %w", eventName, log.TxHash.Hex(), err)
+		}
+		events = append(events, fields)
+	}
+	return events, nil
+}
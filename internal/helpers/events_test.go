@@ -0,0 +1,49 @@
+package helpers
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+const transferEventABI = `[{"anonymous":false,"type":"event","name":"Transfer","inputs":[
+	{"indexed":true,"name":"from","type":"address"},
+	{"indexed":true,"name":"to","type":"address"},
+	{"indexed":false,"name":"value","type":"uint256"}]}]`
+
+func TestDecodeEvents(t *testing.T) {
+	tokenABI, err := abi.JSON(strings.NewReader(transferEventABI))
+	require.NoError(t, err)
+
+	from := common.HexToAddress("0x01")
+	to := common.HexToAddress("0x02")
+	data, err := tokenABI.Events["Transfer"].Inputs.NonIndexed().Pack(big.NewInt(42))
+	require.NoError(t, err)
+
+	receipt := &types.Receipt{Logs: []*types.Log{
+		{Topics: []common.Hash{common.HexToHash("0xdead")}},
+		{
+			Topics: []common.Hash{tokenABI.Events["Transfer"].ID, common.BytesToHash(from.Bytes()), common.BytesToHash(to.Bytes())},
+			Data:   data,
+		},
+	}}
+
+	events, err := DecodeEvents(receipt, tokenABI, "Transfer")
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, from, events[0]["from"])
+	require.Equal(t, to, events[0]["to"])
+	require.Equal(t, big.NewInt(42), events[0]["value"])
+
+	events, err = DecodeEvents(&types.Receipt{}, tokenABI, "Transfer")
+	require.NoError(t, err)
+	require.Empty(t, events)
+
+	_, err = DecodeEvents(receipt, tokenABI, "Approval")
+	require.ErrorContains(t, err, "event Approval not found in ABI")
+}
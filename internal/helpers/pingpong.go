@@ -0,0 +1,110 @@
+package helpers
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// PingPongEvent is a decoded PING or PONG event emitted by the ping-pong contract.
+// Fields whose argument is absent from the event are left zero.
+type PingPongEvent struct {
+	// Name is PING or PONG
+	Name string
+	// Message is the first string argument of the event, the name of the call that answered it
+	Message     string
+	SessionID   *big.Int
+	Data        []byte
+	BlockNumber uint64
+	LogIndex    uint
+	TxHash      common.Hash
+}
+
+/*
+BuildPing packs the ping calldata sent on the source rollup, which expects pongSender to answer
+with a pong from otherChain under the same session ID.
+*/
+func BuildPing(otherChain *big.Int, pongSender, pingReceiver common.Address, sessionID *big.Int, data []byte, pingPongABI abi.ABI) ([]byte, error) {
+	calldata, err := pingPongABI.Pack("ping",
+		otherChain,   // otherChain
+		pongSender,   // pongSender
+		pingReceiver, // pingReceiver
+		sessionID,    // sessionId
+		data,         // data
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack ping: %w", err)
+	}
+	return calldata, nil
+}
+
+/*
+BuildPong packs the pong calldata sent on the destination rollup, answering the ping of pingSender
+from otherChain under the same session ID.
+*/
+func BuildPong(otherChain *big.Int, pingSender common.Address, sessionID *big.Int, data []byte, pingPongABI abi.ABI) ([]byte, error) {
+	calldata, err := pingPongABI.Pack("pong",
+		otherChain, // otherChain
+		pingSender, // pingSender
+		sessionID,  // sessionId
+		data,       // data
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack pong: %w", err)
+	}
+	return calldata, nil
+}
+
+// DecodePingEvent decodes a PING log of the ping-pong contract
+func DecodePingEvent(log *types.Log, pingPongABI abi.ABI) (PingPongEvent, error) {
+	return decodePingPongEvent("PING", log, pingPongABI)
+}
+
+// DecodePongEvent decodes a PONG log of the ping-pong contract
+func DecodePongEvent(log *types.Log, pingPongABI abi.ABI) (PingPongEvent, error) {
+	return decodePingPongEvent("PONG", log, pingPongABI)
+}
+
+// decodePingPongEvent decodes log as the event called name, failing if the log was emitted by another event
+func decodePingPongEvent(name string, log *types.Log, pingPongABI abi.ABI) (PingPongEvent, error) {
+	event, ok := pingPongABI.Events[name]
+	if !ok {
+		return PingPongEvent{}, fmt.Errorf("ping-pong ABI has no %s event", name)
+	}
+	if len(log.Topics) == 0 || log.Topics[0] != event.ID {
+		return PingPongEvent{}, fmt.Errorf("log %d in tx %s is not a %s event", log.Index, log.TxHash.Hex(), name)
+	}
+
+	var indexed abi.Arguments
+	for _, arg := range event.Inputs {
+		if arg.Indexed {
+			indexed = append(indexed, arg)
+		}
+	}
+	fields := make(map[string]interface{})
+	if err := pingPongABI.UnpackIntoMap(fields, name, log.Data); err != nil {
+		return PingPongEvent{}, fmt.Errorf("failed to unpack %s data in tx %s: %w", name, log.TxHash.Hex(), err)
+	}
+	if err := abi.ParseTopicsIntoMap(fields, indexed, log.Topics[1:]); err != nil {
+		return PingPongEvent{}, fmt.Errorf("failed to parse %s topics in tx %s: %w", name, log.TxHash.Hex(), err)
+	}
+
+	decoded := PingPongEvent{
+		Name:        name,
+		BlockNumber: log.BlockNumber,
+		LogIndex:    log.Index,
+		TxHash:      log.TxHash,
+	}
+	for _, arg := range event.Inputs {
+		if message, ok := fields[arg.Name].(string); ok {
+			decoded.Message = message
+			break
+		}
+	}
+	decoded.SessionID, _ = fields["sessionId"].(*big.Int)
+	decoded.Data, _ = fields["data"].([]byte)
+	return decoded, nil
+}
@@ -0,0 +1,78 @@
+package helpers
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+const pingPongTestABI = `[
+	{"type":"function","name":"ping","stateMutability":"nonpayable","outputs":[],"inputs":[
+		{"name":"otherChain","type":"uint256"},{"name":"pongSender","type":"address"},{"name":"pingReceiver","type":"address"},
+		{"name":"sessionId","type":"uint256"},{"name":"data","type":"bytes"}]},
+	{"type":"function","name":"pong","stateMutability":"nonpayable","outputs":[],"inputs":[
+		{"name":"otherChain","type":"uint256"},{"name":"pingSender","type":"address"},
+		{"name":"sessionId","type":"uint256"},{"name":"data","type":"bytes"}]},
+	{"type":"event","name":"PING","anonymous":false,"inputs":[
+		{"name":"message","type":"string","indexed":false},{"name":"sessionId","type":"uint256","indexed":true},
+		{"name":"data","type":"bytes","indexed":false}]},
+	{"type":"event","name":"PONG","anonymous":false,"inputs":[
+		{"name":"message","type":"string","indexed":false},{"name":"sessionId","type":"uint256","indexed":true},
+		{"name":"data","type":"bytes","indexed":false}]}
+]`
+
+func TestPingPongCalldataRoundTrip(t *testing.T) {
+	pingPongABI, err := abi.JSON(strings.NewReader(pingPongTestABI))
+	require.NoError(t, err)
+	pongSender := common.HexToAddress("0x00000000000000000000000000000000000000a1")
+	pingReceiver := common.HexToAddress("0x00000000000000000000000000000000000000b2")
+	sessionID := big.NewInt(4242)
+
+	ping, err := BuildPing(big.NewInt(88888), pongSender, pingReceiver, sessionID, []byte("hello from A"), pingPongABI)
+	require.NoError(t, err)
+	args, err := pingPongABI.Methods["ping"].Inputs.Unpack(ping[4:])
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{big.NewInt(88888), pongSender, pingReceiver, sessionID, []byte("hello from A")}, args)
+
+	pong, err := BuildPong(big.NewInt(77777), pingReceiver, sessionID, []byte("hello from B"), pingPongABI)
+	require.NoError(t, err)
+	args, err = pingPongABI.Methods["pong"].Inputs.Unpack(pong[4:])
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{big.NewInt(77777), pingReceiver, sessionID, []byte("hello from B")}, args)
+}
+
+func TestDecodePingPongEvents(t *testing.T) {
+	pingPongABI, err := abi.JSON(strings.NewReader(pingPongTestABI))
+	require.NoError(t, err)
+	newLog := func(name, message string) *types.Log {
+		event := pingPongABI.Events[name]
+		data, err := event.Inputs.NonIndexed().Pack(message, []byte("payload"))
+		require.NoError(t, err)
+		return &types.Log{
+			Topics:      []common.Hash{event.ID, common.BigToHash(big.NewInt(4242))},
+			Data:        data,
+			BlockNumber: 7,
+			TxHash:      common.HexToHash("0xabc"),
+		}
+	}
+
+	ping, err := DecodePingEvent(newLog("PING", "PONG"), pingPongABI)
+	require.NoError(t, err)
+	require.Equal(t, "PING", ping.Name)
+	require.Equal(t, "PONG", ping.Message)
+	require.Equal(t, big.NewInt(4242), ping.SessionID)
+	require.Equal(t, []byte("payload"), ping.Data)
+	require.Equal(t, uint64(7), ping.BlockNumber)
+
+	pong, err := DecodePongEvent(newLog("PONG", "PING"), pingPongABI)
+	require.NoError(t, err)
+	require.Equal(t, "PING", pong.Message)
+
+	_, err = DecodePongEvent(newLog("PING", "PONG"), pingPongABI)
+	require.ErrorContains(t, err, "is not a PONG event")
+}
@@ -0,0 +1,120 @@
+package helpers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/compose-network/dome/configs"
+	"github.com/compose-network/dome/internal/accounts"
+	"github.com/compose-network/dome/internal/logger"
+	"github.com/compose-network/dome/internal/transactions"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// roundTripSettleTimeout bounds how long BridgeRoundTrip waits for balances to return to their initial values
+const roundTripSettleTimeout = 30 * time.Second
+
+/*
+BridgeRoundTrip bridges amount tokens from a to b and back again, cycles times, and checks that the token
+balances of both accounts end where they started. a and b must be on different rollups, a must hold amount tokens,
+and both must have approved the bridge, since b sends the tokens back. Nonces are taken from the accounts' nonce
+managers, which are resynced first.
+*/
+func BridgeRoundTrip(ctx context.Context, a, b *accounts.Account, amount *big.Int, cycles int, tokenABI, bridgeABI abi.ABI) error {
+	token := configs.Values.L2.Contracts[configs.ContractNameToken].Address
+
+	initialA, err := a.GetTokensBalance(ctx, token, tokenABI)
+	if err != nil {
+		return err
+	}
+	initialB, err := b.GetTokensBalance(ctx, token, tokenABI)
+	if err != nil {
+		return err
+	}
+
+	if err := a.Nonces().Reset(ctx); err != nil {
+		return fmt.Errorf("failed to sync nonce of %s on %s: %w", a.GetAddress().Hex(), a.GetRollup().Name(), err)
+	}
+	if err := b.Nonces().Reset(ctx); err != nil {
+		return fmt.Errorf("failed to sync nonce of %s on %s: %w", b.GetAddress().Hex(), b.GetRollup().Name(), err)
+	}
+
+	var txsOnA, txsOnB []*types.Transaction
+	for i := range cycles {
+		txSend, txReceive, err := sendBridgeWithNextNonces(ctx, a, b, amount, tokenABI, bridgeABI)
+		if err != nil {
+			return fmt.Errorf("cycle %d, bridge to %s: %w", i, b.GetRollup().Name(), err)
+		}
+		txsOnA, txsOnB = append(txsOnA, txSend), append(txsOnB, txReceive)
+
+		txSend, txReceive, err = sendBridgeWithNextNonces(ctx, b, a, amount, tokenABI, bridgeABI)
+		if err != nil {
+			return fmt.Errorf("cycle %d, bridge back to %s: %w", i, a.GetRollup().Name(), err)
+		}
+		txsOnB, txsOnA = append(txsOnB, txSend), append(txsOnA, txReceive)
+	}
+
+	if err := waitForSuccessfulReceipts(ctx, txsOnA, a); err != nil {
+		return err
+	}
+	if err := waitForSuccessfulReceipts(ctx, txsOnB, b); err != nil {
+		return err
+	}
+
+	logger.Info("Bridge round trip of %d cycles done, waiting for balances to settle...", cycles)
+	return errors.Join(
+		accounts.WaitForTokenBalance(ctx, a, token, tokenABI, initialA, roundTripSettleTimeout),
+		accounts.WaitForTokenBalance(ctx, b, token, tokenABI, initialB, roundTripSettleTimeout),
+	)
+}
+
+// sendBridgeWithNextNonces builds a bridge from `from` to `to` with the next managed nonces and submits it.
+// The nonces are released again when the bridge is not built or the coordinator rejects it.
+func sendBridgeWithNextNonces(ctx context.Context, from, to *accounts.Account, amount *big.Int, tokenABI, bridgeABI abi.ABI) (*types.Transaction, *types.Transaction, error) {
+	fromNonce, err := from.Nonces().Next(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	toNonce, err := to.Nonces().Next(ctx)
+	if err != nil {
+		from.Nonces().Release(fromNonce)
+		return nil, nil, err
+	}
+	release := func() {
+		from.Nonces().Release(fromNonce)
+		to.Nonces().Release(toNonce)
+	}
+
+	txSend, txReceive, request, err := transactions.BuildBridgeCrossTx(ctx, from, to, amount, tokenABI, bridgeABI,
+		transactions.WithNonces(fromNonce, toNonce))
+	if err != nil {
+		release()
+		return nil, nil, err
+	}
+	if _, err := transactions.SendCrossTxToCoordinator(ctx, from.GetRollup().RPCURL(), request); err != nil {
+		var rejected *transactions.CrossTxRejectedError
+		if errors.As(err, &rejected) {
+			release()
+		}
+		return nil, nil, err
+	}
+	return txSend, txReceive, nil
+}
+
+// waitForSuccessfulReceipts waits for txs on ac's rollup and returns an error if any of them reverted
+func waitForSuccessfulReceipts(ctx context.Context, txs []*types.Transaction, ac *accounts.Account) error {
+	receipts, err := transactions.WaitForReceipts(ctx, txs, ac.GetRollup())
+	if err != nil {
+		return err
+	}
+	for i, receipt := range receipts {
+		if receipt.Status != types.ReceiptStatusSuccessful {
+			return fmt.Errorf("bridge tx %s on %s reverted", txs[i].Hash().Hex(), ac.GetRollup().Name())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package helpers
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/compose-network/dome/configs"
+	"github.com/compose-network/dome/internal/accounts"
+	"github.com/compose-network/dome/internal/rollup"
+	"github.com/compose-network/dome/internal/rpctest"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendBridgeWithNextNoncesReleasesRejectedNonces(t *testing.T) {
+	bridgeABI, err := abi.JSON(strings.NewReader(bridgeCallsABI))
+	require.NoError(t, err)
+	gasFees, coordinatorURL := configs.Values.GasFees, configs.Values.L2.CoordinatorURL
+	t.Cleanup(func() { configs.Values.GasFees, configs.Values.L2.CoordinatorURL = gasFees, coordinatorURL })
+	configs.Values.GasFees = configs.GasFees{TipCapGwei: 1, FeeCapGwei: 20}
+	configs.Values.L2.CoordinatorURL = ""
+
+	newAccount := func(chainID int64, name string) *accounts.Account {
+		server := rpctest.NewServer(t, func(req rpctest.Request) interface{} {
+			switch req.Method {
+			case "eth_chainId":
+				return hexutil.EncodeBig(big.NewInt(chainID))
+			case "eth_getTransactionCount":
+				return "0x3"
+			case "eth_sendXTransaction":
+				return &rpctest.Error{Code: -32000, Message: "cross tx rejected"}
+			}
+			t.Errorf("unexpected method %s", req.Method)
+			return nil
+		})
+		r := rollup.New(server.URL, big.NewInt(chainID), name)
+		t.Cleanup(r.Close)
+		ac, err := accounts.NewRollupAccount("4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318", r)
+		require.NoError(t, err)
+		return ac
+	}
+	a, b := newAccount(77777, "rollup-a"), newAccount(88888, "rollup-b")
+
+	_, _, err = sendBridgeWithNextNonces(t.Context(), a, b, big.NewInt(1), abi.ABI{}, bridgeABI)
+	require.ErrorContains(t, err, "cross tx rejected")
+
+	for _, ac := range []*accounts.Account{a, b} {
+		nonce, err := ac.Nonces().Next(t.Context())
+		require.NoError(t, err)
+		require.Equal(t, uint64(3), nonce, "the rejected bridge's nonce is handed out again on %s", ac.GetRollup().Name())
+	}
+}
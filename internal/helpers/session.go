@@ -0,0 +1,40 @@
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/compose-network/dome/configs"
+	"github.com/compose-network/dome/internal/accounts"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+/*
+GetBridgeSessionState calls the view method of the bridge contract on ac's rollup with sessionID and returns its
+unpacked outputs. Which method exposes a session and how its result encodes the state depend on the bridge
+deployment, so the caller names the method and interprets the values.
+*/
+func GetBridgeSessionState(ctx context.Context, ac *accounts.Account, sessionID *big.Int, bridgeABI abi.ABI, method string) ([]interface{}, error) {
+	calldata, err := bridgeABI.Pack(method, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack %s: %w", method, err)
+	}
+
+	client, err := ac.GetRollup().Client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	bridgeAddr := configs.Values.L2.Contracts[configs.ContractNameBridge].Address
+	data, err := client.CallContract(ctx, ethereum.CallMsg{From: ac.GetAddress(), To: &bridgeAddr, Data: calldata}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call %s on %s: %w", method, ac.GetRollup().Name(), err)
+	}
+
+	values, err := bridgeABI.Unpack(method, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack %s: %w", method, err)
+	}
+	return values, nil
+}
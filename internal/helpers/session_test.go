@@ -0,0 +1,49 @@
+package helpers
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/compose-network/dome/internal/accounts"
+	"github.com/compose-network/dome/internal/rollup"
+	"github.com/compose-network/dome/internal/rpctest"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetBridgeSessionState(t *testing.T) {
+	bridgeABI, err := abi.JSON(strings.NewReader(`[
+		{"type":"function","name":"sessions","stateMutability":"view","inputs":[{"name":"","type":"uint256"}],"outputs":[{"name":"","type":"uint8"}]}
+	]`))
+	require.NoError(t, err)
+	method := bridgeABI.Methods["sessions"]
+	state, err := method.Outputs.Pack(uint8(2))
+	require.NoError(t, err)
+
+	server := rpctest.NewServer(t, func(req rpctest.Request) interface{} {
+		if req.Method == "eth_chainId" {
+			return rpctest.ChainID
+		}
+		var call struct {
+			Input hexutil.Bytes `json:"input"`
+		}
+		assert.NoError(t, json.Unmarshal(req.Params[0], &call))
+		want, err := bridgeABI.Pack("sessions", big.NewInt(4242))
+		assert.NoError(t, err)
+		assert.Equal(t, hexutil.Bytes(want), call.Input)
+		return hexutil.Encode(state)
+	})
+	ac, err := accounts.NewRollupAccount("4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318", rollup.New(server.URL, big.NewInt(77777), "rollup-a"))
+	require.NoError(t, err)
+
+	values, err := GetBridgeSessionState(t.Context(), ac, big.NewInt(4242), bridgeABI, "sessions")
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{uint8(2)}, values)
+
+	_, err = GetBridgeSessionState(t.Context(), ac, big.NewInt(4242), bridgeABI, "sessionState")
+	require.ErrorContains(t, err, "failed to pack sessionState")
+}
@@ -1,8 +1,13 @@
 package logger
 
 import (
+	"fmt"
+	"io"
 	"log"
+	"os"
+	"sort"
 	"strings"
+	"sync"
 )
 
 type LogLevel int
@@ -14,7 +19,30 @@ const (
 	ERROR
 )
 
-var currentLevel LogLevel = INFO
+var (
+	currentLevel LogLevel = INFO
+	output                = &syncWriter{w: os.Stderr}
+	std                   = log.New(output, "", log.LstdFlags)
+)
+
+// syncWriter serializes writes to the underlying writer so it can be swapped while goroutines are logging
+type syncWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (s *syncWriter) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.w.Write(p)
+}
+
+// SetOutput redirects all log output to w
+func SetOutput(w io.Writer) {
+	output.mu.Lock()
+	defer output.mu.Unlock()
+	output.w = w
+}
 
 // SetLogLevel sets the current log level
 func SetLogLevel(level LogLevel) {
@@ -37,35 +65,85 @@ func SetLogLevelFromString(level string) {
 	}
 }
 
+// Logger prepends a fixed set of fields to every line it logs
+type Logger struct {
+	prefix string
+}
+
+// defaultLogger backs the package-level functions and carries no fields
+var defaultLogger = &Logger{}
+
+// With returns a child of the default logger that prepends fields to every line
+func With(fields map[string]any) *Logger {
+	return defaultLogger.With(fields)
+}
+
+// With returns a child logger carrying both l's fields and fields.
+// Fields are rendered as key=value pairs sorted by key.
+func (l *Logger) With(fields map[string]any) *Logger {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString(l.prefix)
+	for _, k := range keys {
+		fmt.Fprintf(&b, "%s=%v ", k, fields[k])
+	}
+	return &Logger{prefix: b.String()}
+}
+
 // Debug logs debug messages
-func Debug(format string, v ...interface{}) {
+func (l *Logger) Debug(format string, v ...interface{}) {
 	if currentLevel <= DEBUG {
-		log.Printf("[DEBUG] "+format, v...)
+		std.Printf("[DEBUG] %s%s", l.prefix, fmt.Sprintf(format, v...))
 	}
 }
 
 // Info logs info messages
-func Info(format string, v ...interface{}) {
+func (l *Logger) Info(format string, v ...interface{}) {
 	if currentLevel <= INFO {
-		log.Printf("[INFO] "+format, v...)
+		std.Printf("[INFO] %s%s", l.prefix, fmt.Sprintf(format, v...))
 	}
 }
 
 // Warn logs warning messages
-func Warn(format string, v ...interface{}) {
+func (l *Logger) Warn(format string, v ...interface{}) {
 	if currentLevel <= WARN {
-		log.Printf("[WARN] "+format, v...)
+		std.Printf("[WARN] %s%s", l.prefix, fmt.Sprintf(format, v...))
 	}
 }
 
 // Error logs error messages
-func Error(format string, v ...interface{}) {
+func (l *Logger) Error(format string, v ...interface{}) {
 	if currentLevel <= ERROR {
-		log.Printf("[ERROR] "+format, v...)
+		std.Printf("[ERROR] %s%s", l.prefix, fmt.Sprintf(format, v...))
 	}
 }
 
+// Debug logs debug messages
+func Debug(format string, v ...interface{}) {
+	defaultLogger.Debug(format, v...)
+}
+
+// Info logs info messages
+func Info(format string, v ...interface{}) {
+	defaultLogger.Info(format, v...)
+}
+
+// Warn logs warning messages
+func Warn(format string, v ...interface{}) {
+	defaultLogger.Warn(format, v...)
+}
+
+// Error logs error messages
+func Error(format string, v ...interface{}) {
+	defaultLogger.Error(format, v...)
+}
+
 // Fatal logs fatal messages and exits
 func Fatal(format string, v ...interface{}) {
-	log.Fatalf("[FATAL] "+format, v...)
+	std.Fatalf("[FATAL] "+format, v...)
 }
@@ -0,0 +1,47 @@
+package logger
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetOutputCapturesLogs(t *testing.T) {
+	var buf bytes.Buffer
+	SetOutput(&buf)
+	t.Cleanup(func() { SetOutput(os.Stderr) })
+
+	SetLogLevel(INFO)
+	Debug("hidden %d", 1)
+	Info("hello %s", "world")
+
+	require.Contains(t, buf.String(), "[INFO] hello world\n")
+	require.NotContains(t, buf.String(), "hidden")
+}
+
+func TestWithPrependsFields(t *testing.T) {
+	var buf bytes.Buffer
+	SetOutput(&buf)
+	t.Cleanup(func() { SetOutput(os.Stderr) })
+
+	SetLogLevel(INFO)
+	l := With(map[string]any{"session_id": 7, "chain": "rollup-a"})
+	l.With(map[string]any{"tx_hash": "0xabc"}).Warn("sent %d", 1)
+	Info("plain")
+
+	require.Contains(t, buf.String(), "[WARN] chain=rollup-a session_id=7 tx_hash=0xabc sent 1\n")
+	require.Contains(t, buf.String(), "[INFO] plain\n")
+}
+
+func TestWithKeepsPercentInFieldValues(t *testing.T) {
+	var buf bytes.Buffer
+	SetOutput(&buf)
+	t.Cleanup(func() { SetOutput(os.Stderr) })
+
+	SetLogLevel(INFO)
+	With(map[string]any{"reason": "100% used %s"}).Info("sent %d", 1)
+
+	require.Contains(t, buf.String(), "[INFO] reason=100% used %s sent 1\n")
+}
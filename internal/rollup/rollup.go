@@ -1,24 +1,90 @@
 package rollup
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"math/big"
+	"sync"
+
+	"github.com/compose-network/dome/internal/logger"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
 )
 
+// ErrNoWSURL is returned by SubscribeNewHeads when the rollup has no WebSocket endpoint
+var ErrNoWSURL = errors.New("no WebSocket URL configured")
+
+// ErrNoRPCURL is returned by Client when the rollup was created without any RPC endpoint
+var ErrNoRPCURL = errors.New("no RPC URL configured")
+
 type Rollup struct {
-	rpcURL  string
+	rpcURLs []string
 	chainID *big.Int
 	name    string
+	headers map[string]string
+	wsURL   string
+
+	mu        sync.Mutex
+	client    *ethclient.Client
+	activeURL string
 }
 
 func New(rpcURL string, chainID *big.Int, name string) *Rollup {
+	return NewWithFallbacks([]string{rpcURL}, chainID, name)
+}
+
+// NewWithFallbacks creates a rollup that connects to the first reachable endpoint of rpcURLs, in order
+func NewWithFallbacks(rpcURLs []string, chainID *big.Int, name string) *Rollup {
 	return &Rollup{
-		rpcURL:  rpcURL,
+		rpcURLs: rpcURLs,
 		chainID: chainID,
 		name:    name,
 	}
 }
+
+// WithHeaders sets HTTP headers sent with every request of the shared client and returns the rollup.
+// It must be called before the first dial.
+func (r *Rollup) WithHeaders(headers map[string]string) *Rollup {
+	r.headers = headers
+	return r
+}
+
+// WithWSURL sets the WebSocket endpoint used by SubscribeNewHeads and returns the rollup
+func (r *Rollup) WithWSURL(wsURL string) *Rollup {
+	r.wsURL = wsURL
+	return r
+}
+
+// WSURL returns the WebSocket endpoint, or an empty string if none is configured
+func (r *Rollup) WSURL() string {
+	return r.wsURL
+}
+
+// Headers returns the HTTP headers sent with every RPC request
+func (r *Rollup) Headers() map[string]string {
+	return r.headers
+}
+
+// RPCURL returns the endpoint the shared client is connected to, or the primary endpoint before the first dial.
+// It is empty if the rollup has no endpoints.
 func (r *Rollup) RPCURL() string {
-	return r.rpcURL
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.activeURL != "" {
+		return r.activeURL
+	}
+	if len(r.rpcURLs) == 0 {
+		return ""
+	}
+	return r.rpcURLs[0]
+}
+
+// RPCURLs returns all configured endpoints in failover order
+func (r *Rollup) RPCURLs() []string {
+	return r.rpcURLs
 }
 
 func (r *Rollup) ChainID() *big.Int {
@@ -28,3 +94,212 @@ func (r *Rollup) ChainID() *big.Int {
 func (r *Rollup) Name() string {
 	return r.name
 }
+
+// Client returns the shared RPC client for this rollup, dialing it on first use.
+// Endpoints are tried in order; one that fails to dial or to answer eth_chainId is skipped.
+// The client is owned by the rollup and must not be closed by callers.
+func (r *Rollup) Client(ctx context.Context) (*ethclient.Client, error) {
+	r.mu.Lock()
+	client := r.client
+	r.mu.Unlock()
+	if client != nil {
+		return client, nil
+	}
+	if len(r.rpcURLs) == 0 {
+		return nil, fmt.Errorf("rollup %s: %w", r.name, ErrNoRPCURL)
+	}
+
+	// dial without holding the lock so a slow endpoint does not block RPCURL or Close
+	client, url, err := r.dial(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.client != nil {
+		// another caller finished dialing first; share its client
+		client.Close()
+		return r.client, nil
+	}
+	r.client = client
+	r.activeURL = url
+	return client, nil
+}
+
+// dial connects to the first endpoint of rpcURLs that answers eth_chainId
+func (r *Rollup) dial(ctx context.Context) (*ethclient.Client, string, error) {
+	var errs error
+	for _, url := range r.rpcURLs {
+		rpcClient, err := rpc.DialOptions(ctx, url, HeaderOptions(r.headers)...)
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("failed to connect to RPC URL %s: %w", url, err))
+			continue
+		}
+		client := ethclient.NewClient(rpcClient)
+		// http clients dial lazily, so probe the endpoint before settling on it
+		if _, err := client.ChainID(ctx); err != nil {
+			client.Close()
+			errs = errors.Join(errs, fmt.Errorf("failed to connect to RPC URL %s: %w", url, err))
+			continue
+		}
+
+		if url != r.rpcURLs[0] {
+			logger.Warn("Rollup %s failed over to RPC URL %s", r.name, url)
+		}
+		return client, url, nil
+	}
+	return nil, "", errs
+}
+
+// HeaderOptions converts headers into rpc dial options
+func HeaderOptions(headers map[string]string) []rpc.ClientOption {
+	opts := make([]rpc.ClientOption, 0, len(headers))
+	for key, value := range headers {
+		opts = append(opts, rpc.WithHeader(key, value))
+	}
+	return opts
+}
+
+// Close closes the shared RPC client, if one was dialed. A later call to Client dials a new one.
+func (r *Rollup) Close() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.client != nil {
+		r.client.Close()
+		r.client = nil
+		r.activeURL = ""
+	}
+}
+
+// HealthCheck verifies that the rollup's RPC endpoint is reachable and reports the configured chain ID
+func (r *Rollup) HealthCheck(ctx context.Context) error {
+	client, err := r.Client(ctx)
+	if err != nil {
+		return fmt.Errorf("rollup %s is unreachable: %w", r.name, err)
+	}
+
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return fmt.Errorf("rollup %s is unreachable at %s: %w", r.name, r.RPCURL(), err)
+	}
+	if chainID.Cmp(r.chainID) != 0 {
+		return fmt.Errorf("rollup %s at %s reports chain ID %s, expected %s", r.name, r.RPCURL(), chainID, r.chainID)
+	}
+	return nil
+}
+
+// BlockNumber returns the number of the most recent block on this rollup
+func (r *Rollup) BlockNumber(ctx context.Context) (uint64, error) {
+	client, err := r.Client(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	number, err := client.BlockNumber(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get block number on %s: %w", r.name, err)
+	}
+	return number, nil
+}
+
+// HeaderByNumber returns the header of block n on this rollup, or the latest header if n is nil
+func (r *Rollup) HeaderByNumber(ctx context.Context, n *big.Int) (*types.Header, error) {
+	client, err := r.Client(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := client.HeaderByNumber(ctx, n)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get header %v on %s: %w", n, r.name, err)
+	}
+	return header, nil
+}
+
+// SuggestGasPrice returns the node's suggested gas price for legacy transactions
+func (r *Rollup) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	client, err := r.Client(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	gasPrice, err := client.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to suggest gas price on %s: %w", r.name, err)
+	}
+	return gasPrice, nil
+}
+
+// SuggestFees returns EIP-1559 fee caps for a new transaction: the node's suggested tip and
+// a fee cap of twice the pending block's base fee plus that tip.
+func (r *Rollup) SuggestFees(ctx context.Context) (tipCap, feeCap *big.Int, err error) {
+	client, err := r.Client(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tipCap, err = client.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to suggest gas tip cap on %s: %w", r.name, err)
+	}
+
+	header, err := client.HeaderByNumber(ctx, big.NewInt(int64(rpc.PendingBlockNumber)))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get pending header on %s: %w", r.name, err)
+	}
+	if header.BaseFee == nil {
+		return nil, nil, fmt.Errorf("pending header on %s has no base fee, chain does not support EIP-1559", r.name)
+	}
+
+	feeCap = new(big.Int).Add(new(big.Int).Mul(header.BaseFee, big.NewInt(2)), tipCap)
+	return tipCap, feeCap, nil
+}
+
+// SubscribeNewHeads subscribes to new block headers over the rollup's WebSocket endpoint.
+// The subscription uses its own connection, which is closed together with the returned channel
+// when ctx is done or the subscription fails.
+func (r *Rollup) SubscribeNewHeads(ctx context.Context) (<-chan *types.Header, error) {
+	if r.wsURL == "" {
+		return nil, fmt.Errorf("cannot subscribe to new heads on %s: %w", r.name, ErrNoWSURL)
+	}
+
+	rpcClient, err := rpc.DialOptions(ctx, r.wsURL, HeaderOptions(r.headers)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to WebSocket URL %s: %w", r.wsURL, err)
+	}
+	client := ethclient.NewClient(rpcClient)
+
+	heads := make(chan *types.Header)
+	sub, err := client.SubscribeNewHead(ctx, heads)
+	if err != nil {
+		client.Close()
+		return nil, fmt.Errorf("failed to subscribe to new heads on %s: %w", r.name, err)
+	}
+
+	out := make(chan *types.Header)
+	go func() {
+		defer close(out)
+		defer client.Close()
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case err := <-sub.Err():
+				if err != nil {
+					logger.Warn("New heads subscription on %s failed: %v", r.name, err)
+				}
+				return
+			case header := <-heads:
+				select {
+				case out <- header:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return out, nil
+}
@@ -0,0 +1,137 @@
+package rollup
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/compose-network/dome/internal/rpctest"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newChainIDServer starts a JSON-RPC server that answers eth_chainId with chainID
+func newChainIDServer(t *testing.T, chainID string) *httptest.Server {
+	t.Helper()
+	return newRPCServer(t, map[string]string{"eth_chainId": chainID})
+}
+
+// newRPCServer starts a JSON-RPC server answering each method in results with its value
+func newRPCServer(t *testing.T, results map[string]string) *httptest.Server {
+	t.Helper()
+	return rpctest.NewServer(t, func(req rpctest.Request) interface{} {
+		result, ok := results[req.Method]
+		assert.True(t, ok, "unexpected method %s", req.Method)
+		return result
+	})
+}
+
+func TestHealthCheck(t *testing.T) {
+	server := newChainIDServer(t, "0x12fd1") // 77777
+
+	healthy := New(server.URL, big.NewInt(77777), "rollup-a")
+	defer healthy.Close()
+	require.NoError(t, healthy.HealthCheck(t.Context()))
+
+	mismatched := New(server.URL, big.NewInt(88888), "rollup-b")
+	defer mismatched.Close()
+	require.ErrorContains(t, mismatched.HealthCheck(t.Context()), "reports chain ID 77777, expected 88888")
+
+	unreachable := New("http://127.0.0.1:1", big.NewInt(77777), "rollup-c")
+	defer unreachable.Close()
+	require.ErrorContains(t, unreachable.HealthCheck(t.Context()), "rollup rollup-c is unreachable")
+}
+
+func TestClientFailsOverToNextURL(t *testing.T) {
+	server := newChainIDServer(t, "0x12fd1") // 77777
+
+	r := NewWithFallbacks([]string{"http://127.0.0.1:1", server.URL}, big.NewInt(77777), "rollup-a")
+	defer r.Close()
+	require.Equal(t, "http://127.0.0.1:1", r.RPCURL())
+
+	_, err := r.Client(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, server.URL, r.RPCURL())
+	require.Equal(t, []string{"http://127.0.0.1:1", server.URL}, r.RPCURLs())
+	require.NoError(t, r.HealthCheck(t.Context()))
+
+	unreachable := NewWithFallbacks([]string{"http://127.0.0.1:1", "http://127.0.0.1:2"}, big.NewInt(77777), "rollup-b")
+	defer unreachable.Close()
+	_, err = unreachable.Client(t.Context())
+	require.ErrorContains(t, err, "http://127.0.0.1:2")
+}
+
+func TestClientWithoutRPCURLs(t *testing.T) {
+	r := NewWithFallbacks(nil, big.NewInt(77777), "rollup-a")
+	require.Empty(t, r.RPCURL())
+	_, err := r.Client(t.Context())
+	require.ErrorIs(t, err, ErrNoRPCURL)
+}
+
+func TestClientDialsOutsideTheLock(t *testing.T) {
+	dialing := make(chan struct{})
+	release := make(chan struct{})
+	var probes atomic.Int32
+	server := rpctest.NewServer(t, func(req rpctest.Request) interface{} {
+		if probes.Add(1) == 1 {
+			close(dialing)
+			<-release
+		}
+		return rpctest.ChainID
+	})
+	r := New(server.URL, big.NewInt(77777), "rollup-a")
+	defer r.Close()
+
+	clients := make(chan *ethclient.Client, 1)
+	go func() {
+		client, err := r.Client(t.Context())
+		assert.NoError(t, err)
+		clients <- client
+	}()
+	<-dialing
+
+	// the first dial is stuck on its eth_chainId probe, which must not block other callers
+	require.Equal(t, server.URL, r.RPCURL())
+	second, err := r.Client(t.Context())
+	require.NoError(t, err)
+	close(release)
+	require.Same(t, second, <-clients, "concurrent dials settle on one shared client")
+}
+
+func TestSuggestGasPrice(t *testing.T) {
+	server := newRPCServer(t, map[string]string{
+		"eth_chainId":  "0x12fd1",
+		"eth_gasPrice": "0x3b9aca00", // 1 gwei
+	})
+	r := New(server.URL, big.NewInt(77777), "rollup-a")
+	defer r.Close()
+
+	gasPrice, err := r.SuggestGasPrice(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, 1, gasPrice.Sign())
+	require.Equal(t, big.NewInt(1000000000), gasPrice)
+}
+
+func TestClientSendsConfiguredHeaders(t *testing.T) {
+	var authorization atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization.Store(r.Header.Get("Authorization"))
+		var req struct {
+			ID json.RawMessage `json:"id"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": "0x2"})
+	}))
+	t.Cleanup(server.Close)
+
+	r := New(server.URL, big.NewInt(2), "rollup-b").WithHeaders(map[string]string{"Authorization": "Bearer token"})
+	t.Cleanup(r.Close)
+
+	require.NoError(t, r.HealthCheck(t.Context()))
+	require.Equal(t, "Bearer token", authorization.Load())
+}
@@ -0,0 +1,74 @@
+package rollup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// ErrTxPoolUnavailable is returned by the tx pool helpers when the node does not expose the txpool namespace
+var ErrTxPoolUnavailable = errors.New("txpool namespace not available")
+
+// methodNotFoundCode is the JSON-RPC error code for unknown or disabled methods
+const methodNotFoundCode = -32601
+
+// TxPoolContent returns the transactions in the node's pool, split into pending (executable) and queued (nonce gap)
+// ones and keyed by sender. Each sender's transactions are sorted by nonce.
+func (r *Rollup) TxPoolContent(ctx context.Context) (pending, queued map[common.Address][]*types.Transaction, err error) {
+	var content struct {
+		Pending map[common.Address]map[string]*types.Transaction `json:"pending"`
+		Queued  map[common.Address]map[string]*types.Transaction `json:"queued"`
+	}
+	if err := r.callTxPool(ctx, &content, "txpool_content"); err != nil {
+		return nil, nil, err
+	}
+	return flattenTxPool(content.Pending), flattenTxPool(content.Queued), nil
+}
+
+// TxPoolStatus returns the number of pending and queued transactions in the node's pool
+func (r *Rollup) TxPoolStatus(ctx context.Context) (pending, queued uint64, err error) {
+	var status struct {
+		Pending hexutil.Uint64 `json:"pending"`
+		Queued  hexutil.Uint64 `json:"queued"`
+	}
+	if err := r.callTxPool(ctx, &status, "txpool_status"); err != nil {
+		return 0, 0, err
+	}
+	return uint64(status.Pending), uint64(status.Queued), nil
+}
+
+func (r *Rollup) callTxPool(ctx context.Context, result interface{}, method string) error {
+	client, err := r.Client(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := client.Client().CallContext(ctx, result, method); err != nil {
+		var rpcErr rpc.Error
+		if errors.As(err, &rpcErr) && rpcErr.ErrorCode() == methodNotFoundCode {
+			return fmt.Errorf("failed to call %s on %s: %w: %w", method, r.name, ErrTxPoolUnavailable, err)
+		}
+		return fmt.Errorf("failed to call %s on %s: %w", method, r.name, err)
+	}
+	return nil
+}
+
+// flattenTxPool turns the nonce-keyed transactions of each sender into a nonce-sorted slice
+func flattenTxPool(bySender map[common.Address]map[string]*types.Transaction) map[common.Address][]*types.Transaction {
+	flattened := make(map[common.Address][]*types.Transaction, len(bySender))
+	for sender, byNonce := range bySender {
+		txs := make([]*types.Transaction, 0, len(byNonce))
+		for _, tx := range byNonce {
+			txs = append(txs, tx)
+		}
+		sort.Slice(txs, func(i, j int) bool { return txs[i].Nonce() < txs[j].Nonce() })
+		flattened[sender] = txs
+	}
+	return flattened
+}
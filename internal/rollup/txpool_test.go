@@ -0,0 +1,75 @@
+package rollup
+
+import (
+	"math/big"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/compose-network/dome/internal/rpctest"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// newTxPoolServer starts a JSON-RPC server for chain 2 answering txpool methods with results.
+// Methods missing from results are answered with a method not found error.
+func newTxPoolServer(t *testing.T, results map[string]interface{}) *httptest.Server {
+	t.Helper()
+	return rpctest.NewServer(t, func(req rpctest.Request) interface{} {
+		if result, ok := results[req.Method]; ok {
+			return result
+		}
+		if req.Method == "eth_chainId" {
+			return "0x2"
+		}
+		return &rpctest.Error{Code: -32601, Message: "the method " + req.Method + " does not exist/is not available"}
+	})
+}
+
+func TestTxPoolContent(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	sender := crypto.PubkeyToAddress(key.PublicKey)
+	signer := types.LatestSignerForChainID(big.NewInt(2))
+	signed := make([]*types.Transaction, 3)
+	for i := range signed {
+		signed[i], err = types.SignNewTx(key, signer, &types.DynamicFeeTx{
+			ChainID: big.NewInt(2), Nonce: uint64(i), Gas: 21000, GasTipCap: big.NewInt(1), GasFeeCap: big.NewInt(2), To: &common.Address{},
+		})
+		require.NoError(t, err)
+	}
+
+	server := newTxPoolServer(t, map[string]interface{}{
+		"txpool_content": map[string]interface{}{
+			"pending": map[string]interface{}{sender.Hex(): map[string]interface{}{"1": signed[1], "0": signed[0]}},
+			"queued":  map[string]interface{}{sender.Hex(): map[string]interface{}{"2": signed[2]}},
+		},
+		"txpool_status": map[string]string{"pending": "0x2", "queued": "0x1"},
+	})
+	r := New(server.URL, big.NewInt(2), "rollup-b")
+	defer r.Close()
+
+	pending, queued, err := r.TxPoolContent(t.Context())
+	require.NoError(t, err)
+	require.Len(t, pending[sender], 2)
+	require.Equal(t, signed[0].Hash(), pending[sender][0].Hash())
+	require.Equal(t, signed[1].Hash(), pending[sender][1].Hash())
+	require.Len(t, queued[sender], 1)
+	require.Equal(t, signed[2].Hash(), queued[sender][0].Hash())
+
+	pendingCount, queuedCount, err := r.TxPoolStatus(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), pendingCount)
+	require.Equal(t, uint64(1), queuedCount)
+}
+
+func TestTxPoolUnavailable(t *testing.T) {
+	r := New(newTxPoolServer(t, nil).URL, big.NewInt(2), "rollup-b")
+	defer r.Close()
+
+	_, _, err := r.TxPoolStatus(t.Context())
+	require.ErrorIs(t, err, ErrTxPoolUnavailable)
+	_, _, err = r.TxPoolContent(t.Context())
+	require.ErrorIs(t, err, ErrTxPoolUnavailable)
+}
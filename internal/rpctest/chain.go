@@ -0,0 +1,112 @@
+package rpctest
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// SignTx returns a dynamic fee transfer on chain 77777 with the given nonce, signed by key
+func SignTx(t testing.TB, key *ecdsa.PrivateKey, nonce uint64) *types.Transaction {
+	t.Helper()
+	chainID := big.NewInt(77777)
+	tx, err := types.SignNewTx(key, types.LatestSignerForChainID(chainID), &types.DynamicFeeTx{
+		ChainID: chainID, Nonce: nonce, To: &common.Address{}, Gas: 21000, GasTipCap: big.NewInt(1), GasFeeCap: big.NewInt(1),
+	})
+	if err != nil {
+		t.Fatalf("failed to sign test transaction: %v", err)
+	}
+	return tx
+}
+
+// PendingTx is the eth_getTransactionByHash result for tx while it waits in the mempool
+func PendingTx(t testing.TB, tx *types.Transaction) map[string]interface{} {
+	t.Helper()
+	encoded, err := json.Marshal(tx)
+	if err != nil {
+		t.Errorf("failed to encode transaction %s: %v", tx.Hash(), err)
+		return nil
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(encoded, &fields); err != nil {
+		t.Errorf("failed to decode transaction %s: %v", tx.Hash(), err)
+		return nil
+	}
+	return fields
+}
+
+// MinedTx is the eth_getTransactionByHash result for tx once it is included in block
+func MinedTx(t testing.TB, tx *types.Transaction, block uint64) map[string]interface{} {
+	t.Helper()
+	fields := PendingTx(t, tx)
+	if fields != nil {
+		fields["blockHash"] = blockHash(block)
+		fields["blockNumber"] = hexutil.Uint64(block)
+	}
+	return fields
+}
+
+// Receipt is the eth_getTransactionReceipt result for txHash included in block with the given status
+func Receipt(txHash common.Hash, status, block uint64) *types.Receipt {
+	return &types.Receipt{Status: status, TxHash: txHash, BlockHash: blockHash(block), BlockNumber: new(big.Int).SetUint64(block), Logs: []*types.Log{}}
+}
+
+// Chain answers eth_getTransactionByHash and eth_getTransactionReceipt for the transactions mined on it.
+// Hashes that were not mined are answered with null, like a node that has not seen the transaction.
+// It is safe for concurrent use.
+type Chain struct {
+	t     testing.TB
+	mu    sync.Mutex
+	mined map[common.Hash]minedTx
+}
+
+type minedTx struct {
+	tx     *types.Transaction
+	status uint64
+	block  uint64
+}
+
+// NewChain returns an empty chain reporting malformed lookups to t
+func NewChain(t testing.TB) *Chain {
+	return &Chain{t: t, mined: make(map[common.Hash]minedTx)}
+}
+
+// Mine includes tx in block with the given receipt status
+func (c *Chain) Mine(tx *types.Transaction, status, block uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.mined[tx.Hash()] = minedTx{tx: tx, status: status, block: block}
+}
+
+// Handle answers req if it looks up a transaction or receipt and reports whether it did
+func (c *Chain) Handle(req Request) (interface{}, bool) {
+	if req.Method != "eth_getTransactionByHash" && req.Method != "eth_getTransactionReceipt" {
+		return nil, false
+	}
+	var hash common.Hash
+	if len(req.Params) == 0 || json.Unmarshal(req.Params[0], &hash) != nil {
+		c.t.Errorf("%s called without a transaction hash", req.Method)
+		return nil, true
+	}
+
+	c.mu.Lock()
+	mined, ok := c.mined[hash]
+	c.mu.Unlock()
+	if !ok {
+		return nil, true
+	}
+	if req.Method == "eth_getTransactionByHash" {
+		return MinedTx(c.t, mined.tx, mined.block), true
+	}
+	return Receipt(hash, mined.status, mined.block), true
+}
+
+func blockHash(block uint64) common.Hash {
+	return common.BigToHash(new(big.Int).SetUint64(block))
+}
@@ -0,0 +1,85 @@
+// Package rpctest provides a fake JSON-RPC node for unit tests that run without a live rollup.
+package rpctest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// ChainID is the hex encoded eth_chainId answer for the chain 77777 rollups used throughout the unit tests
+const ChainID = "0x12fd1"
+
+// Request is a decoded JSON-RPC request
+type Request struct {
+	ID     json.RawMessage   `json:"id"`
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+}
+
+// Error is a JSON-RPC error; a handler returns it to answer a call with an error instead of a result
+type Error struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+// NewServer starts a JSON-RPC server answering every call with the value returned by handle.
+// Returning an *Error responds with that JSON-RPC error. Batch requests are answered call by call.
+// The server is closed when t finishes.
+func NewServer(t testing.TB, handle func(req Request) interface{}) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("failed to read JSON-RPC request: %v", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		var response interface{}
+		if batch := bytes.TrimSpace(body); len(batch) > 0 && batch[0] == '[' {
+			var reqs []Request
+			if err := json.Unmarshal(batch, &reqs); err != nil {
+				t.Errorf("failed to decode JSON-RPC batch request: %v", err)
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			responses := make([]map[string]interface{}, len(reqs))
+			for i, req := range reqs {
+				responses[i] = respond(req, handle)
+			}
+			response = responses
+		} else {
+			var req Request
+			if err := json.Unmarshal(body, &req); err != nil {
+				t.Errorf("failed to decode JSON-RPC request: %v", err)
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			response = respond(req, handle)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			t.Errorf("failed to encode JSON-RPC response: %v", err)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// respond builds the JSON-RPC response to req from the value returned by handle
+func respond(req Request, handle func(req Request) interface{}) map[string]interface{} {
+	response := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+	result := handle(req)
+	if rpcErr, ok := result.(*Error); ok {
+		response["error"] = rpcErr
+	} else {
+		response["result"] = result
+	}
+	return response
+}
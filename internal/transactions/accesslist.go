@@ -0,0 +1,63 @@
+package transactions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/compose-network/dome/internal/accounts"
+	"github.com/compose-network/dome/internal/logger"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const createAccessListRPCMethod = "eth_createAccessList"
+
+type accessListResult struct {
+	AccessList *types.AccessList `json:"accessList"`
+	Error      string            `json:"error,omitempty"`
+	GasUsed    hexutil.Uint64    `json:"gasUsed"`
+}
+
+// AccessListFor generates the EIP-2930 access list for details sent from ac using eth_createAccessList.
+// The result can be assigned to details.AccessList before calling CreateTransaction.
+func AccessListFor(ctx context.Context, details TransactionDetails, ac *accounts.Account) (types.AccessList, error) {
+	client, err := ac.GetRollup().Client(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	arg := map[string]interface{}{
+		"from": ac.GetAddress(),
+		"to":   details.To,
+		"data": hexutil.Bytes(details.Data),
+	}
+	if details.Value != nil {
+		arg["value"] = (*hexutil.Big)(details.Value)
+	}
+	if details.Gas != 0 {
+		arg["gas"] = hexutil.Uint64(details.Gas)
+	}
+	if details.Legacy && details.GasPrice != nil {
+		arg["gasPrice"] = (*hexutil.Big)(details.GasPrice)
+	}
+	if !details.Legacy && details.GasTipCap != nil {
+		arg["maxPriorityFeePerGas"] = (*hexutil.Big)(details.GasTipCap)
+	}
+	if !details.Legacy && details.GasFeeCap != nil {
+		arg["maxFeePerGas"] = (*hexutil.Big)(details.GasFeeCap)
+	}
+
+	var result accessListResult
+	if err := client.Client().CallContext(ctx, &result, createAccessListRPCMethod, arg, "latest"); err != nil {
+		return nil, fmt.Errorf("failed to create access list: %w", err)
+	}
+	if result.Error != "" {
+		return nil, fmt.Errorf("failed to create access list: execution error: %s", result.Error)
+	}
+	if result.AccessList == nil {
+		return types.AccessList{}, nil
+	}
+
+	logger.Debug("Access list created on %s with %d entries (gas used: %d)", ac.GetRollup().Name(), len(*result.AccessList), uint64(result.GasUsed))
+	return *result.AccessList, nil
+}
@@ -0,0 +1,73 @@
+package transactions
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/compose-network/dome/internal/rollup"
+	"github.com/compose-network/dome/internal/rpctest"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccessListFor(t *testing.T) {
+	list := types.AccessList{{
+		Address:     common.HexToAddress("0x1111111111111111111111111111111111111111"),
+		StorageKeys: []common.Hash{common.HexToHash("0x01")},
+	}}
+	var result interface{} = map[string]interface{}{"accessList": list, "gasUsed": "0x6d60"}
+	server := rpctest.NewServer(t, func(req rpctest.Request) interface{} {
+		if req.Method == "eth_chainId" {
+			return rpctest.ChainID
+		}
+		assert.Equal(t, createAccessListRPCMethod, req.Method)
+		var arg map[string]string
+		assert.NoError(t, json.Unmarshal(req.Params[0], &arg))
+		assert.Equal(t, "0x0000000000000000000000000000000000000001", arg["to"])
+		assert.Equal(t, "0x3b9aca00", arg["maxPriorityFeePerGas"])
+		assert.NotContains(t, arg, "gasPrice")
+		assert.JSONEq(t, `"latest"`, string(req.Params[1]))
+		return result
+	})
+	ac := newTestAccountOn(t, rollup.New(server.URL, big.NewInt(77777), "test-rollup"))
+	details := TransactionDetails{To: common.HexToAddress("0x01"), GasTipCap: big.NewInt(1000000000), GasFeeCap: big.NewInt(2000000000)}
+
+	got, err := AccessListFor(t.Context(), details, ac)
+	require.NoError(t, err)
+	require.Equal(t, list, got)
+
+	result = map[string]interface{}{"accessList": nil, "gasUsed": "0x5208"}
+	got, err = AccessListFor(t.Context(), details, ac)
+	require.NoError(t, err)
+	require.Empty(t, got)
+
+	result = map[string]interface{}{"accessList": []interface{}{}, "error": "execution reverted", "gasUsed": "0x0"}
+	_, err = AccessListFor(t.Context(), details, ac)
+	require.EqualError(t, err, "failed to create access list: execution error: execution reverted")
+}
+
+func TestCreateTransactionWithAccessList(t *testing.T) {
+	ac := newTestAccount(t)
+	list := types.AccessList{{Address: common.HexToAddress("0x02"), StorageKeys: []common.Hash{common.HexToHash("0x03")}}}
+	details := TransactionDetails{
+		To:         common.HexToAddress("0x01"),
+		Value:      big.NewInt(0),
+		Gas:        30000,
+		GasTipCap:  big.NewInt(1000000000),
+		GasFeeCap:  big.NewInt(2000000000),
+		GasPrice:   big.NewInt(2000000000),
+		AccessList: list,
+	}
+
+	tx, _, err := CreateTransactionWithNonce(t.Context(), details, ac, 0)
+	require.NoError(t, err)
+	require.Equal(t, uint8(types.DynamicFeeTxType), tx.Type())
+	require.Equal(t, list, tx.AccessList())
+
+	details.Legacy = true
+	_, _, err = CreateTransactionWithNonce(t.Context(), details, ac, 0)
+	require.ErrorContains(t, err, "access lists are not supported by legacy transactions")
+}
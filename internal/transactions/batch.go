@@ -0,0 +1,125 @@
+package transactions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/compose-network/dome/internal/rollup"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// ExpectedOutcome is whether a transaction of a BatchResult should succeed, revert or never be mined
+type ExpectedOutcome int
+
+const (
+	ExpectSuccess ExpectedOutcome = iota
+	ExpectFailure
+	// ExpectNotIncluded matches a transaction that was dropped or rejected and never reached the node.
+	// It is only decided once ctx is done, so Check must be given a ctx with a deadline.
+	ExpectNotIncluded
+)
+
+func (o ExpectedOutcome) String() string {
+	switch o {
+	case ExpectFailure:
+		return "failure"
+	case ExpectNotIncluded:
+		return "not included"
+	}
+	return "success"
+}
+
+// ErrUnexpectedOutcome is returned by BatchResult.Check for a transaction whose status does not match its expected outcome
+var ErrUnexpectedOutcome = errors.New("unexpected transaction outcome")
+
+// BatchEntry is a submitted transaction together with the rollup it was sent to and its expected outcome
+type BatchEntry struct {
+	Tx     *types.Transaction
+	Rollup *rollup.Rollup
+	Expect ExpectedOutcome
+}
+
+// BatchResult collects the transactions of a batch so that all of them can be verified at once.
+// The zero value is ready to use and Add is safe for concurrent use.
+type BatchResult struct {
+	mu      sync.Mutex
+	entries []BatchEntry
+}
+
+// Add records tx as sent to rollup with the given expected outcome
+func (b *BatchResult) Add(tx *types.Transaction, rollup *rollup.Rollup, expect ExpectedOutcome) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.entries = append(b.entries, BatchEntry{Tx: tx, Rollup: rollup, Expect: expect})
+}
+
+// Entries returns a copy of the recorded entries in the order they were added
+func (b *BatchResult) Entries() []BatchEntry {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]BatchEntry(nil), b.entries...)
+}
+
+// Check waits concurrently for the receipts of all entries and returns every mismatch between
+// a receipt's status and the expected outcome, joined into one error.
+// Transactions that have not reached the node yet are polled until ctx is done, so ctx's deadline sets the budget.
+func (b *BatchResult) Check(ctx context.Context) error {
+	entries := b.Entries()
+	errs := make([]error, len(entries))
+
+	var wg sync.WaitGroup
+	for i, entry := range entries {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = checkOutcome(ctx, entry)
+		}()
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// Verify runs Check and fails t if any transaction did not have its expected outcome
+func (b *BatchResult) Verify(ctx context.Context, t testing.TB) {
+	t.Helper()
+	if err := b.Check(ctx); err != nil {
+		t.Fatalf("batch of %d txs did not match the expected outcomes:\n%v", len(b.Entries()), err)
+	}
+}
+
+func checkOutcome(ctx context.Context, entry BatchEntry) error {
+	txHash := entry.Tx.Hash()
+	if entry.Expect == ExpectNotIncluded {
+		// polled without notifying the observer, as not finding the tx is the expected outcome
+		_, receipt, err := pollTransactionDetails(ctx, txHash, entry.Rollup, WaitOpts{NotFoundRetries: -1}.withDefaults())
+		if errors.Is(err, ErrReceiptNotFound) {
+			return nil
+		}
+		if err == nil {
+			return fmt.Errorf("%w: tx %s on %s expected %s, but it was mined in block %s", ErrUnexpectedOutcome, txHash.Hex(), entry.Rollup.Name(), entry.Expect, receipt.BlockNumber)
+		}
+		return fmt.Errorf("failed waiting for tx %s on %s: %w", txHash.Hex(), entry.Rollup.Name(), err)
+	}
+
+	_, receipt, err := GetTransactionDetailsWithOpts(ctx, txHash, entry.Rollup, WaitOpts{NotFoundRetries: -1})
+	if err != nil {
+		return fmt.Errorf("failed waiting for tx %s on %s: %w", txHash.Hex(), entry.Rollup.Name(), err)
+	}
+
+	succeeded := receipt.Status == types.ReceiptStatusSuccessful
+	if succeeded == (entry.Expect == ExpectSuccess) {
+		return nil
+	}
+	if succeeded {
+		return fmt.Errorf("%w: tx %s on %s expected %s, but it succeeded", ErrUnexpectedOutcome, txHash.Hex(), entry.Rollup.Name(), entry.Expect)
+	}
+	reason, err := GetReceiptRevertReason(ctx, entry.Tx, entry.Rollup, receipt)
+	if err != nil {
+		reason = fmt.Sprintf("unavailable: %v", err)
+	}
+	return fmt.Errorf("%w: tx %s on %s expected %s, but it reverted (gas used %d of %d, reason: %s)", ErrUnexpectedOutcome, txHash.Hex(), entry.Rollup.Name(), entry.Expect, receipt.GasUsed, entry.Tx.Gas(), reason)
+}
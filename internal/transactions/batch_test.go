@@ -0,0 +1,103 @@
+package transactions
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/compose-network/dome/internal/rollup"
+	"github.com/compose-network/dome/internal/rpctest"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchResultCheckReportsMismatches(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	chain := rpctest.NewChain(t)
+	newTx := func(nonce uint64, status uint64) *types.Transaction {
+		tx := rpctest.SignTx(t, key, nonce)
+		chain.Mine(tx, status, 1)
+		return tx
+	}
+	succeeded := newTx(0, types.ReceiptStatusSuccessful)
+	reverted := newTx(1, types.ReceiptStatusFailed)
+	unexpected := newTx(2, types.ReceiptStatusSuccessful)
+
+	server := rpctest.NewServer(t, func(req rpctest.Request) interface{} {
+		if req.Method == "eth_chainId" {
+			return rpctest.ChainID
+		}
+		if req.Method == "eth_call" {
+			return "0x"
+		}
+		result, _ := chain.Handle(req)
+		return result
+	})
+	r := rollup.New(server.URL, big.NewInt(77777), "test-rollup")
+	defer r.Close()
+
+	var batch BatchResult
+	batch.Add(succeeded, r, ExpectSuccess)
+	batch.Add(reverted, r, ExpectFailure)
+	require.NoError(t, batch.Check(t.Context()))
+
+	batch.Add(unexpected, r, ExpectFailure)
+	err = batch.Check(t.Context())
+	require.ErrorIs(t, err, ErrUnexpectedOutcome)
+	require.ErrorContains(t, err, unexpected.Hash().Hex())
+	require.NotContains(t, err.Error(), succeeded.Hash().Hex())
+	require.Len(t, batch.Entries(), 3)
+}
+
+func TestBatchResultCheckExpectNotIncluded(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	mined := rpctest.SignTx(t, key, 0)
+	dropped := rpctest.SignTx(t, key, 1)
+
+	// only mined ever reaches the node
+	chain := rpctest.NewChain(t)
+	chain.Mine(mined, types.ReceiptStatusSuccessful, 1)
+	server := rpctest.NewServer(t, func(req rpctest.Request) interface{} {
+		if req.Method == "eth_chainId" {
+			return rpctest.ChainID
+		}
+		result, _ := chain.Handle(req)
+		return result
+	})
+	r := rollup.New(server.URL, big.NewInt(77777), "test-rollup")
+	defer r.Close()
+	check := func(batch *BatchResult) error {
+		ctx, cancel := context.WithTimeout(t.Context(), 100*time.Millisecond)
+		defer cancel()
+		return batch.Check(ctx)
+	}
+
+	o := &LatencyObserver{}
+	SetObserver(o)
+	t.Cleanup(func() { SetObserver(nil) })
+
+	var batch BatchResult
+	batch.Add(mined, r, ExpectSuccess)
+	batch.Add(dropped, r, ExpectNotIncluded)
+	require.NoError(t, check(&batch))
+	_, confirmed, failed := o.Counts()
+	require.Equal(t, 1, confirmed)
+	require.Zero(t, failed, "a tx expected not to be included is not a failure")
+
+	// a dropped tx is still an error for entries expecting a receipt
+	var expectFailure BatchResult
+	expectFailure.Add(dropped, r, ExpectFailure)
+	err = check(&expectFailure)
+	require.ErrorIs(t, err, ErrReceiptNotFound)
+	require.NotErrorIs(t, err, ErrUnexpectedOutcome)
+
+	var minedAnyway BatchResult
+	minedAnyway.Add(mined, r, ExpectNotIncluded)
+	err = check(&minedAnyway)
+	require.ErrorIs(t, err, ErrUnexpectedOutcome)
+	require.ErrorContains(t, err, "expected not included, but it was mined in block 1")
+}
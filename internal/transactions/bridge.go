@@ -0,0 +1,153 @@
+package transactions
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/compose-network/dome/configs"
+	"github.com/compose-network/dome/internal/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// BridgeOpt customizes the transactions built by BuildBridgeCrossTx
+type BridgeOpt func(*bridgeOpts)
+
+type bridgeOpts struct {
+	fromNonce  *uint64
+	toNonce    *uint64
+	sendGas    uint64
+	receiveGas uint64
+	sessionID  *big.Int
+}
+
+// WithNonces signs the send leg with fromNonce and the receive leg with toNonce instead of the pending nonces
+func WithNonces(fromNonce, toNonce uint64) BridgeOpt {
+	return func(o *bridgeOpts) {
+		o.fromNonce = &fromNonce
+		o.toNonce = &toNonce
+	}
+}
+
+// WithBridgeGas overrides the gas limit of both legs, which otherwise comes from the configured gas profile
+func WithBridgeGas(gas uint64) BridgeOpt {
+	return func(o *bridgeOpts) {
+		o.sendGas = gas
+		o.receiveGas = gas
+	}
+}
+
+// WithSessionID uses sessionID for both legs instead of a random one
+func WithSessionID(sessionID *big.Int) BridgeOpt {
+	return func(o *bridgeOpts) {
+		o.sessionID = sessionID
+	}
+}
+
+/*
+BuildBridgeCrossTx builds a bridge of amount tokens from `from` to `to`: a send call on from's rollup,
+a matching receiveTokens call on to's rollup and the encoded cross tx request bundling both.
+Nothing is sent.
+*/
+func BuildBridgeCrossTx(
+	ctx context.Context,
+	from *accounts.Account,
+	to *accounts.Account,
+	amount *big.Int,
+	tokenABI abi.ABI,
+	bridgeABI abi.ABI,
+	opts ...BridgeOpt,
+) (*types.Transaction, *types.Transaction, []byte, error) {
+	o := bridgeOpts{
+		sendGas:    configs.Values.GasProfile.Gas(configs.GasOpBridgeSend),
+		receiveGas: configs.Values.GasProfile.Gas(configs.GasOpBridgeReceive),
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.sessionID == nil {
+		o.sessionID = GenerateRandomSessionID()
+	}
+
+	bridgeAddr := configs.Values.L2.Contracts[configs.ContractNameBridge].Address
+	tokenAddr := configs.Values.L2.Contracts[configs.ContractNameToken].Address
+
+	calldataSend, err := bridgeABI.Pack("send",
+		to.GetRollup().ChainID(), // otherChainId
+		tokenAddr,                // token
+		from.GetAddress(),        // sender
+		to.GetAddress(),          // receiver
+		amount,                   // amount
+		o.sessionID,              // sessionId
+		bridgeAddr,               // destBridge
+	)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to pack send: %w", err)
+	}
+
+	calldataReceive, err := ReceiveCalldataFromSend(calldataSend, from.GetRollup().ChainID(), bridgeAddr, bridgeABI)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	txSend, rawSend, err := createWithOptionalNonce(ctx, NewTxDetails(bridgeAddr).Gas(o.sendGas).Data(calldataSend).Build(), from, o.fromNonce)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to create send tx: %w", err)
+	}
+	txReceive, rawReceive, err := createWithOptionalNonce(ctx, NewTxDetails(bridgeAddr).Gas(o.receiveGas).Data(calldataReceive).Build(), to, o.toNonce)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to create receive tx: %w", err)
+	}
+
+	request, err := CreateCrossTxRequestMsg(ctx, from, to, rawSend, rawReceive)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return txSend, txReceive, request, nil
+}
+
+/*
+ReceiveCalldataFromSend decodes bridge send calldata and packs the receiveTokens calldata of the matching receive leg,
+taking sender, receiver and session ID from the send call. srcChainID and srcBridge are the chain and bridge the send
+leg is sent to, which the send call itself does not name. The arguments are read by position, in the order
+BuildBridgeCrossTx packs them, so the ABI's argument names do not matter.
+*/
+func ReceiveCalldataFromSend(sendCalldata []byte, srcChainID *big.Int, srcBridge common.Address, bridgeABI abi.ABI) ([]byte, error) {
+	send, ok := bridgeABI.Methods["send"]
+	if !ok {
+		return nil, fmt.Errorf("bridge ABI has no send method")
+	}
+	if len(sendCalldata) < 4 || !bytes.Equal(sendCalldata[:4], send.ID) {
+		return nil, fmt.Errorf("calldata is not a bridge send call")
+	}
+	args, err := send.Inputs.Unpack(sendCalldata[4:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode send calldata: %w", err)
+	}
+	if len(args) != 7 {
+		return nil, fmt.Errorf("bridge send takes %d arguments, expected 7", len(args))
+	}
+
+	calldata, err := bridgeABI.Pack("receiveTokens",
+		srcChainID, // chainSrc
+		args[2],    // sender
+		args[3],    // receiver
+		args[5],    // sessionId
+		srcBridge,  // srcBridge
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack receiveTokens: %w", err)
+	}
+	return calldata, nil
+}
+
+// createWithOptionalNonce signs details with nonce, or with the account's pending nonce when it is nil
+func createWithOptionalNonce(ctx context.Context, details TransactionDetails, ac *accounts.Account, nonce *uint64) (*types.Transaction, []byte, error) {
+	if nonce != nil {
+		return CreateTransactionWithNonce(ctx, details, ac, *nonce)
+	}
+	return CreateTransaction(ctx, details, ac)
+}
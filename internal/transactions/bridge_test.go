@@ -0,0 +1,66 @@
+package transactions
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/compose-network/dome/configs"
+	"github.com/compose-network/dome/internal/rollup"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/stretchr/testify/require"
+)
+
+const testBridgeABI = `[
+	{"type":"function","name":"send","stateMutability":"nonpayable","outputs":[],"inputs":[
+		{"name":"otherChainId","type":"uint256"},{"name":"token","type":"address"},{"name":"sender","type":"address"},
+		{"name":"receiver","type":"address"},{"name":"amount","type":"uint256"},{"name":"sessionId","type":"uint256"},
+		{"name":"destBridge","type":"address"}]},
+	{"type":"function","name":"receiveTokens","stateMutability":"nonpayable","outputs":[],"inputs":[
+		{"name":"chainSrc","type":"uint256"},{"name":"sender","type":"address"},{"name":"receiver","type":"address"},
+		{"name":"sessionId","type":"uint256"},{"name":"srcBridge","type":"address"}]}
+]`
+
+func TestBuildBridgeCrossTx(t *testing.T) {
+	bridgeABI, err := abi.JSON(strings.NewReader(testBridgeABI))
+	require.NoError(t, err)
+	from := newTestAccountOn(t, rollup.New("http://127.0.0.1:0", big.NewInt(77777), "rollup-a"))
+	to := newTestAccountOn(t, rollup.New("http://127.0.0.1:0", big.NewInt(88888), "rollup-b"))
+	sessionID := big.NewInt(42)
+	gasFees := configs.Values.GasFees
+	t.Cleanup(func() { configs.Values.GasFees = gasFees })
+	configs.Values.GasFees = configs.GasFees{TipCapGwei: 1, FeeCapGwei: 20}
+	amount := big.NewInt(1000)
+
+	txSend, txReceive, request, err := BuildBridgeCrossTx(t.Context(), from, to, amount, abi.ABI{}, bridgeABI,
+		WithNonces(3, 9), WithBridgeGas(500000), WithSessionID(sessionID))
+	require.NoError(t, err)
+
+	bridgeAddr := configs.Values.L2.Contracts[configs.ContractNameBridge].Address
+	require.Equal(t, uint64(3), txSend.Nonce())
+	require.Equal(t, uint64(9), txReceive.Nonce())
+	require.Equal(t, uint64(500000), txSend.Gas())
+	require.Equal(t, bridgeAddr, *txSend.To())
+	require.Equal(t, big.NewInt(77777), txSend.ChainId())
+	require.Equal(t, big.NewInt(88888), txReceive.ChainId())
+
+	send, err := bridgeABI.Methods["send"].Inputs.Unpack(txSend.Data()[4:])
+	require.NoError(t, err)
+	receive, err := bridgeABI.Methods["receiveTokens"].Inputs.Unpack(txReceive.Data()[4:])
+	require.NoError(t, err)
+	require.Equal(t, to.GetRollup().ChainID(), send[0])
+	require.Equal(t, amount, send[4])
+	require.Equal(t, from.GetRollup().ChainID(), receive[0])
+	require.Equal(t, send[2], receive[1], "sender")
+	require.Equal(t, send[3], receive[2], "receiver")
+	require.Equal(t, sessionID, send[5])
+	require.Equal(t, sessionID, receive[3])
+
+	xtRequest, err := DecodeCrossTxRequest(request)
+	require.NoError(t, err)
+	txRequests := xtRequest.GetTransactions()
+	require.Len(t, txRequests, 2)
+	rawSend, err := RawBytes(txSend)
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{rawSend}, txRequests[0].Transaction)
+}
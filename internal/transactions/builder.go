@@ -0,0 +1,79 @@
+package transactions
+
+import (
+	"math/big"
+
+	"github.com/compose-network/dome/configs"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// TxDetailsBuilder builds TransactionDetails. Fees left unset come from the gas-fees config section,
+// or are suggested by the rollup when the transaction is signed.
+type TxDetailsBuilder struct {
+	details TransactionDetails
+}
+
+// NewTxDetails starts building the details of a transaction to the given address
+func NewTxDetails(to common.Address) *TxDetailsBuilder {
+	return &TxDetailsBuilder{details: TransactionDetails{To: to}}
+}
+
+// Value sets the amount of wei sent with the transaction
+func (b *TxDetailsBuilder) Value(value *big.Int) *TxDetailsBuilder {
+	b.details.Value = value
+	return b
+}
+
+// Data sets the transaction calldata
+func (b *TxDetailsBuilder) Data(data []byte) *TxDetailsBuilder {
+	b.details.Data = data
+	return b
+}
+
+// Gas sets the gas limit. Without it the limit is estimated.
+func (b *TxDetailsBuilder) Gas(gas uint64) *TxDetailsBuilder {
+	b.details.Gas = gas
+	return b
+}
+
+// Fees sets the EIP-1559 tip and fee caps
+func (b *TxDetailsBuilder) Fees(tipCap, feeCap *big.Int) *TxDetailsBuilder {
+	b.details.GasTipCap = tipCap
+	b.details.GasFeeCap = feeCap
+	return b
+}
+
+// GasPrice makes the transaction a legacy one priced with gasPrice. A nil gasPrice is suggested
+// by the rollup when the transaction is signed.
+func (b *TxDetailsBuilder) GasPrice(gasPrice *big.Int) *TxDetailsBuilder {
+	b.details.Legacy = true
+	b.details.GasPrice = gasPrice
+	return b
+}
+
+// Build returns the transaction details, filling a zero value and the configured fees where unset.
+// Fees that are neither set nor configured stay nil.
+func (b *TxDetailsBuilder) Build() TransactionDetails {
+	details := b.details
+	if details.Value == nil {
+		details.Value = big.NewInt(0)
+	}
+	if details.Legacy {
+		return details
+	}
+	if details.GasTipCap == nil {
+		details.GasTipCap = configuredFee(configs.Values.GasFees.TipCapGwei)
+	}
+	if details.GasFeeCap == nil {
+		details.GasFeeCap = configuredFee(configs.Values.GasFees.FeeCapGwei)
+	}
+	return details
+}
+
+// configuredFee returns the configured gwei amount in wei, or nil when it is unset
+func configuredFee(configuredGwei int64) *big.Int {
+	if configuredGwei > 0 {
+		return GWei(configuredGwei)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package transactions
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/compose-network/dome/configs"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxDetailsBuilder(t *testing.T) {
+	to := common.HexToAddress("0x01")
+
+	details := NewTxDetails(to).Build()
+	require.Equal(t, TransactionDetails{
+		To:    to,
+		Value: big.NewInt(0),
+	}, details)
+
+	details = NewTxDetails(to).
+		Value(big.NewInt(5)).
+		Data([]byte{0xab}).
+		Gas(900000).
+		Fees(big.NewInt(1000000), big.NewInt(2000000)).
+		Build()
+	require.Equal(t, TransactionDetails{
+		To:        to,
+		Value:     big.NewInt(5),
+		Data:      []byte{0xab},
+		Gas:       900000,
+		GasTipCap: big.NewInt(1000000),
+		GasFeeCap: big.NewInt(2000000),
+	}, details)
+}
+
+func TestTxDetailsBuilderGasPrice(t *testing.T) {
+	to := common.HexToAddress("0x01")
+
+	details := NewTxDetails(to).GasPrice(big.NewInt(3000000)).Build()
+	require.Equal(t, TransactionDetails{
+		To:       to,
+		Value:    big.NewInt(0),
+		Legacy:   true,
+		GasPrice: big.NewInt(3000000),
+	}, details)
+
+	details = NewTxDetails(to).GasPrice(nil).Build()
+	require.True(t, details.Legacy)
+	require.Nil(t, details.GasPrice)
+}
+
+func TestTxDetailsBuilderUsesConfiguredFees(t *testing.T) {
+	previous := configs.Values.GasFees
+	t.Cleanup(func() { configs.Values.GasFees = previous })
+	configs.Values.GasFees = configs.GasFees{TipCapGwei: 2}
+
+	details := NewTxDetails(common.HexToAddress("0x01")).Build()
+	require.Equal(t, GWei(2), details.GasTipCap)
+	require.Nil(t, details.GasFeeCap)
+
+	details = NewTxDetails(common.HexToAddress("0x01")).GasPrice(GWei(5)).Build()
+	require.Nil(t, details.GasTipCap)
+	require.Equal(t, GWei(5), details.GasPrice)
+}
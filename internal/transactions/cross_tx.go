@@ -2,38 +2,168 @@ package transactions
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/compose-network/dome/configs"
 	"github.com/compose-network/dome/internal/accounts"
 	"github.com/compose-network/dome/internal/logger"
+	"github.com/compose-network/dome/internal/rollup"
 	"github.com/compose-network/dome/pkg/rollupv1"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/rpc"
 	"google.golang.org/protobuf/proto"
 )
 
-const sendTxRPCMethod = "eth_sendXTransaction"
-
-func CreateCrossTxRequestMsg(ctx context.Context, ac1 *accounts.Account, ac2 *accounts.Account, signedTx1 []byte, signedTx2 []byte) ([]byte, error) {
-	xtRequest := &rollupv1.XTRequest{
-		Transactions: []*rollupv1.TransactionRequest{
-			{
-				ChainId: ac1.GetRollup().ChainID().Bytes(),
-				Transaction: [][]byte{
-					signedTx1,
-				},
-			},
-			{
-				ChainId: ac2.GetRollup().ChainID().Bytes(),
-				Transaction: [][]byte{
-					signedTx2,
-				},
-			},
-		},
+const (
+	defaultSenderID = "client"
+
+	sendTxRPCMethod = "eth_sendXTransaction"
+)
+
+// CrossTxResponse is the coordinator's reply to eth_sendXTransaction
+type CrossTxResponse struct {
+	// RequestID is the hex encoded XtID of the submitted XTRequest, derived locally from the payload.
+	// It is empty when the payload is not a cross tx request msg.
+	RequestID string
+	// Result is the coordinator's result as received; its shape is not specified, so it is not decoded
+	Result json.RawMessage
+}
+
+// CrossTxRejectedError is returned when the coordinator answers a cross tx request with a JSON-RPC error.
+// Data holds the error's data field, which often carries structured rejection details.
+type CrossTxRejectedError struct {
+	Code    int
+	Message string
+	Data    interface{}
+}
+
+func (e *CrossTxRejectedError) Error() string {
+	if e.Data == nil {
+		return fmt.Sprintf("cross tx request rejected (code %d): %s", e.Code, e.Message)
+	}
+	return fmt.Sprintf("cross tx request rejected (code %d): %s (data: %v)", e.Code, e.Message, e.Data)
+}
+
+// ErrorCode implements rpc.Error
+func (e *CrossTxRejectedError) ErrorCode() int {
+	return e.Code
+}
+
+// ErrorData implements rpc.DataError
+func (e *CrossTxRejectedError) ErrorData() interface{} {
+	return e.Data
+}
+
+// CrossTxLeg is a single signed transaction of a cross tx together with the account that signed it
+type CrossTxLeg struct {
+	Account  *accounts.Account
+	SignedTx []byte
+}
+
+// CrossTxOpt customizes the message built by the CreateCrossTxRequestMsg functions
+type CrossTxOpt func(*crossTxOpts)
+
+type crossTxOpts struct {
+	senderID     string
+	sessionID    *big.Int
+	forceSession bool
+}
+
+// WithSenderID sets the sender ID of the message, which defaults to "client"
+func WithSenderID(senderID string) CrossTxOpt {
+	return func(o *crossTxOpts) {
+		o.senderID = senderID
+	}
+}
+
+// WithClaimedSessionID claims sessionID in Sessions, failing message creation if it was already used
+func WithClaimedSessionID(sessionID *big.Int) CrossTxOpt {
+	return func(o *crossTxOpts) {
+		o.sessionID = sessionID
+		o.forceSession = false
+	}
+}
+
+// WithForcedSessionID records sessionID in Sessions without failing on reuse, for replay tests
+func WithForcedSessionID(sessionID *big.Int) CrossTxOpt {
+	return func(o *crossTxOpts) {
+		o.sessionID = sessionID
+		o.forceSession = true
+	}
+}
+
+func CreateCrossTxRequestMsg(ctx context.Context, ac1 *accounts.Account, ac2 *accounts.Account, signedTx1 []byte, signedTx2 []byte, opts ...CrossTxOpt) ([]byte, error) {
+	return CreateCrossTxRequestMsgN(ctx, []CrossTxLeg{
+		{Account: ac1, SignedTx: signedTx1},
+		{Account: ac2, SignedTx: signedTx2},
+	}, opts...)
+}
+
+// CreateCrossTxRequestMsgN builds an encoded cross tx request from any number of legs.
+// Legs are grouped by chain ID into one TransactionRequest per chain. Chains appear in the order
+// of their first leg, and legs on the same chain keep their relative order.
+func CreateCrossTxRequestMsgN(ctx context.Context, legs []CrossTxLeg, opts ...CrossTxOpt) ([]byte, error) {
+	rawLegs := make([]RawLeg, len(legs))
+	for i, leg := range legs {
+		chainID := leg.Account.GetRollup().ChainID()
+		if !chainID.IsUint64() {
+			return nil, fmt.Errorf("chain ID %s of cross tx leg %d on %s does not fit in 64 bits", chainID, i, leg.Account.GetRollup().Name())
+		}
+		rawLegs[i] = RawLeg{ChainID: chainID.Uint64(), SignedTx: leg.SignedTx}
+	}
+	return CreateCrossTxRequestMsgRaw(rawLegs, opts...)
+}
+
+// RawLeg is a single signed transaction of a cross tx together with the ID of the chain it targets
+type RawLeg struct {
+	ChainID  uint64
+	SignedTx []byte
+}
+
+// CreateCrossTxRequestMsgRaw builds an encoded cross tx request from raw legs, grouping them like CreateCrossTxRequestMsgN.
+// It needs no accounts, so captured or hand-crafted transactions can be replayed.
+func CreateCrossTxRequestMsgRaw(legs []RawLeg, opts ...CrossTxOpt) ([]byte, error) {
+	if len(legs) == 0 {
+		return nil, fmt.Errorf("at least one cross tx leg must be provided")
+	}
+	o := crossTxOpts{senderID: defaultSenderID}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.sessionID != nil {
+		if o.forceSession {
+			Sessions.ForceClaim(o.sessionID)
+		} else if err := Sessions.Claim(o.sessionID); err != nil {
+			return nil, fmt.Errorf("failed to claim session ID: %w", err)
+		}
+	}
+
+	xtRequest := &rollupv1.XTRequest{}
+	byChain := make(map[uint64]*rollupv1.TransactionRequest, len(legs))
+	for _, leg := range legs {
+		txRequest, ok := byChain[leg.ChainID]
+		if !ok {
+			txRequest = &rollupv1.TransactionRequest{
+				ChainId: new(big.Int).SetUint64(leg.ChainID).Bytes(),
+			}
+			byChain[leg.ChainID] = txRequest
+			xtRequest.Transactions = append(xtRequest.Transactions, txRequest)
+		}
+		txRequest.Transaction = append(txRequest.Transaction, leg.SignedTx)
 	}
 
 	spMsg := &rollupv1.Message{
-		SenderId: "client",
+		SenderId: o.senderID,
 		Payload: &rollupv1.Message_XtRequest{
 			XtRequest: xtRequest,
 		},
@@ -47,18 +177,224 @@ func CreateCrossTxRequestMsg(ctx context.Context, ac1 *accounts.Account, ac2 *ac
 	return encodedPayload, nil
 }
 
-func SendCrossTxRequestMsg(ctx context.Context, rpcURL string, encodedPayload []byte) error {
-	l1Client, err := rpc.Dial(rpcURL)
+// CreateCrossTxRequestMsgGrouped builds an encoded cross tx request carrying several signed txs per chain.
+// The txs of each account are packed, in slice order, into the single TransactionRequest of that account's chain,
+// so the rollup executes them in that order. Chains are emitted sorted by chain ID so the encoding is deterministic.
+// Each chain may be keyed by only one account; use CreateCrossTxRequestMsgN to interleave several signers on a chain.
+func CreateCrossTxRequestMsgGrouped(ctx context.Context, perChain map[*accounts.Account][][]byte, opts ...CrossTxOpt) ([]byte, error) {
+	signers := make([]*accounts.Account, 0, len(perChain))
+	seenChains := make(map[string]struct{}, len(perChain))
+	for ac := range perChain {
+		chainID := ac.GetRollup().ChainID().String()
+		if _, ok := seenChains[chainID]; ok {
+			return nil, fmt.Errorf("multiple accounts provided for chain %s", chainID)
+		}
+		seenChains[chainID] = struct{}{}
+		signers = append(signers, ac)
+	}
+	sort.Slice(signers, func(i, j int) bool {
+		return signers[i].GetRollup().ChainID().Cmp(signers[j].GetRollup().ChainID()) < 0
+	})
+
+	var legs []CrossTxLeg
+	for _, ac := range signers {
+		for _, signedTx := range perChain[ac] {
+			legs = append(legs, CrossTxLeg{Account: ac, SignedTx: signedTx})
+		}
+	}
+	return CreateCrossTxRequestMsgN(ctx, legs, opts...)
+}
+
+// dialCoordinator connects to rpcURL with the headers configured for the chain that lists it
+func dialCoordinator(ctx context.Context, rpcURL string) (*rpc.Client, error) {
+	return rpc.DialOptions(ctx, rpcURL, rollup.HeaderOptions(configs.Values.L2.HeadersForURL(rpcURL))...)
+}
+
+// DecodeCrossTxRequest decodes a request built by CreateCrossTxRequestMsg and returns its XTRequest
+func DecodeCrossTxRequest(encoded []byte) (*rollupv1.XTRequest, error) {
+	var msg rollupv1.Message
+	if err := proto.Unmarshal(encoded, &msg); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal cross tx request msg: %w", err)
+	}
+	xtRequest := msg.GetXtRequest()
+	if xtRequest == nil {
+		return nil, fmt.Errorf("cross tx request msg from %q does not carry an XTRequest", msg.GetSenderId())
+	}
+	return xtRequest, nil
+}
+
+// PrettyPrint renders xtRequest with one line per chain ID followed by the hash of each of its txs
+func PrettyPrint(xtRequest *rollupv1.XTRequest) string {
+	var b strings.Builder
+	for _, txRequest := range xtRequest.GetTransactions() {
+		fmt.Fprintf(&b, "chain %s:\n", new(big.Int).SetBytes(txRequest.GetChainId()))
+		for _, signedTx := range txRequest.GetTransaction() {
+			fmt.Fprintf(&b, "\t%s\n", crypto.Keccak256Hash(signedTx).Hex())
+		}
+	}
+	return b.String()
+}
+
+// CrossTxSendOpts controls timeouts and retries of SendCrossTxRequestMsgWithOpts
+type CrossTxSendOpts struct {
+	// Timeout bounds each attempt; zero means only ctx applies
+	Timeout time.Duration
+	// MaxRetries is the number of extra attempts made after a connection error or an HTTP 5xx response
+	MaxRetries int
+	// Backoff is the wait before the first retry, doubled after every further failure
+	Backoff time.Duration
+}
+
+// CoordinatorURL returns the configured coordinator-url, or fallbackRPCURL when none is set
+func CoordinatorURL(fallbackRPCURL string) string {
+	if configs.Values.L2.CoordinatorURL != "" {
+		return configs.Values.L2.CoordinatorURL
+	}
+	return fallbackRPCURL
+}
+
+// SendCrossTxToCoordinator submits the encoded cross tx request to the configured coordinator-url,
+// or to fallbackRPCURL when none is set
+func SendCrossTxToCoordinator(ctx context.Context, fallbackRPCURL string, encodedPayload []byte) (*CrossTxResponse, error) {
+	return SendCrossTxRequestMsg(ctx, CoordinatorURL(fallbackRPCURL), encodedPayload)
+}
+
+// SendCrossTxRequestMsg submits the encoded cross tx request to rpcURL and returns the coordinator's response
+func SendCrossTxRequestMsg(ctx context.Context, rpcURL string, encodedPayload []byte) (*CrossTxResponse, error) {
+	return SendCrossTxRequestMsgWithOpts(ctx, rpcURL, encodedPayload, CrossTxSendOpts{})
+}
+
+// SendCrossTxRequestMsgWithOpts submits the encoded cross tx request to rpcURL, retrying when the coordinator could not
+// be reached or answered with an HTTP 5xx. Timeouts, other HTTP errors and anything after a completed exchange are not
+// retried, since the coordinator may already have accepted the request.
+func SendCrossTxRequestMsgWithOpts(ctx context.Context, rpcURL string, encodedPayload []byte, opts CrossTxSendOpts) (*CrossTxResponse, error) {
+	backoff := opts.Backoff
+	for attempt := 0; ; attempt++ {
+		response, err := sendCrossTxRequestMsgOnce(ctx, rpcURL, encodedPayload, opts.Timeout)
+		if err == nil {
+			return response, nil
+		}
+		if !retryableSendError(err) || attempt >= opts.MaxRetries {
+			return nil, err
+		}
+
+		logger.Warn("Sending cross tx request msg to %s failed, retrying in %s (retry %d/%d): %v", rpcURL, backoff, attempt+1, opts.MaxRetries, err)
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("cross tx request msg not sent after %d attempts: %w", attempt+1, ctx.Err())
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// retryableSendError reports whether err means the request never reached the coordinator: a refused or failed
+// connection, or an HTTP 5xx response
+func retryableSendError(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return false
+	}
+	var httpErr rpc.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode >= http.StatusInternalServerError
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+func sendCrossTxRequestMsgOnce(ctx context.Context, rpcURL string, encodedPayload []byte, timeout time.Duration) (*CrossTxResponse, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	l1Client, err := dialCoordinator(ctx, rpcURL)
 	if err != nil {
-		return fmt.Errorf("could not connect to custom rpc: %v", err)
+		return nil, fmt.Errorf("could not connect to custom rpc: %w", err)
 	}
 	defer l1Client.Close()
 
-	err = l1Client.CallContext(ctx, nil, sendTxRPCMethod, hexutil.Encode(encodedPayload))
+	var result json.RawMessage
+	err = l1Client.CallContext(ctx, &result, sendTxRPCMethod, hexutil.Encode(encodedPayload))
+	if err != nil {
+		var rpcErr rpc.Error
+		if errors.As(err, &rpcErr) {
+			rejected := &CrossTxRejectedError{Code: rpcErr.ErrorCode(), Message: rpcErr.Error()}
+			var dataErr rpc.DataError
+			if errors.As(err, &dataErr) {
+				rejected.Data = dataErr.ErrorData()
+			}
+			return nil, rejected
+		}
+		return nil, fmt.Errorf("RPC call failed: %w", err)
+	}
+
+	response := &CrossTxResponse{Result: result}
+	if response.RequestID, err = CrossTxRequestID(encodedPayload); err != nil {
+		logger.Debug("Cross tx request ID not derived: %v", err)
+	}
+	notifyCrossTxSubmitted(encodedPayload)
+
+	logger.With(map[string]any{"request_id": response.RequestID}).
+		Info("Cross tx request msg sent successfully: %x", encodedPayload)
+	return response, nil
+}
+
+// notifyCrossTxSubmitted reports every leg of an accepted cross tx request msg to the observer as submitted
+func notifyCrossTxSubmitted(encoded []byte) {
+	xtRequest, err := DecodeCrossTxRequest(encoded)
 	if err != nil {
-		return fmt.Errorf("RPC call failed: %v", err)
+		return
+	}
+	for _, txRequest := range xtRequest.GetTransactions() {
+		for _, signedTx := range txRequest.GetTransaction() {
+			currentObserver().OnSubmitted(crypto.Keccak256Hash(signedTx))
+		}
+	}
+}
+
+// CrossTxRequestID returns the hex encoded XtID of the XTRequest carried by an encoded cross tx request msg
+func CrossTxRequestID(encoded []byte) (string, error) {
+	xtRequest, err := DecodeCrossTxRequest(encoded)
+	if err != nil {
+		return "", err
+	}
+	xtID, err := xtRequest.XtID()
+	if err != nil {
+		return "", err
+	}
+	return hexutil.Encode(xtID.Hash), nil
+}
+
+// SendCrossTxBatch submits every encoded cross tx request in msgs to rpcURL, with at most maxConcurrency in flight.
+// The returned errors are aligned with msgs; a nil entry means the request was sent successfully.
+func SendCrossTxBatch(ctx context.Context, msgs [][]byte, rpcURL string, maxConcurrency int) []error {
+	if maxConcurrency < 1 {
+		maxConcurrency = 1
+	}
+
+	errs := make([]error, len(msgs))
+	sem := make(chan struct{}, maxConcurrency)
+	var wg sync.WaitGroup
+	for i, msg := range msgs {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			errs[i] = fmt.Errorf("cross tx request %d not sent: %w", i, ctx.Err())
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if _, err := SendCrossTxRequestMsg(ctx, rpcURL, msg); err != nil {
+				errs[i] = fmt.Errorf("cross tx request %d: %w", i, err)
+			}
+		}()
 	}
+	wg.Wait()
 
-	logger.Info("Cross tx request msg sent successfully: %x", encodedPayload)
-	return nil
+	return errs
 }
@@ -0,0 +1,379 @@
+package transactions
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/compose-network/dome/configs"
+	"github.com/compose-network/dome/internal/accounts"
+	"github.com/compose-network/dome/internal/rollup"
+	"github.com/compose-network/dome/internal/rpctest"
+	"github.com/compose-network/dome/internal/transactions/mock"
+	"github.com/compose-network/dome/pkg/rollupv1"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestSendCrossTxRequestMsgKeepsResult(t *testing.T) {
+	acA := newTestAccountOn(t, rollup.New("http://127.0.0.1:0", big.NewInt(77777), "rollup-a"))
+	acB := newTestAccountOn(t, rollup.New("http://127.0.0.1:0", big.NewInt(88888), "rollup-b"))
+	encoded, err := CreateCrossTxRequestMsg(t.Context(), acA, acB, []byte{0x0a}, []byte{0x0b})
+	require.NoError(t, err)
+	xtRequest, err := DecodeCrossTxRequest(encoded)
+	require.NoError(t, err)
+	xtID, err := xtRequest.XtID()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		result interface{}
+		want   string
+	}{
+		{name: "object", result: map[string]interface{}{"requestId": "0xabc", "accepted": true}, want: `{"accepted":true,"requestId":"0xabc"}`},
+		{name: "string", result: "0xdef", want: `"0xdef"`},
+		{name: "null", result: nil, want: `null`},
+		{name: "unknown shape", result: []int{1, 2}, want: `[1,2]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := rpctest.NewServer(t, func(req rpctest.Request) interface{} {
+				assert.Equal(t, sendTxRPCMethod, req.Method)
+				return tt.result
+			})
+
+			response, err := SendCrossTxRequestMsg(t.Context(), server.URL, encoded)
+			require.NoError(t, err)
+			require.JSONEq(t, tt.want, string(response.Result))
+			require.Equal(t, hexutil.Encode(xtID.Hash), response.RequestID)
+		})
+	}
+}
+
+func TestCreateCrossTxRequestMsgNGroupsLegsByChain(t *testing.T) {
+	acA := newTestAccountOn(t, rollup.New("http://127.0.0.1:0", big.NewInt(77777), "rollup-a"))
+	acB := newTestAccountOn(t, rollup.New("http://127.0.0.1:0", big.NewInt(88888), "rollup-b"))
+
+	encoded, err := CreateCrossTxRequestMsgN(t.Context(), []CrossTxLeg{
+		{Account: acA, SignedTx: []byte{0x0a}},
+		{Account: acB, SignedTx: []byte{0x0b}},
+		{Account: acA, SignedTx: []byte{0x0c}},
+	})
+	require.NoError(t, err)
+
+	xtRequest, err := DecodeCrossTxRequest(encoded)
+	require.NoError(t, err)
+	txRequests := xtRequest.GetTransactions()
+	require.Len(t, txRequests, 2)
+	require.Equal(t, big.NewInt(77777).Bytes(), txRequests[0].ChainId)
+	require.Equal(t, [][]byte{{0x0a}, {0x0c}}, txRequests[0].Transaction)
+	require.Equal(t, big.NewInt(88888).Bytes(), txRequests[1].ChainId)
+	require.Equal(t, [][]byte{{0x0b}}, txRequests[1].Transaction)
+}
+
+func TestCreateCrossTxRequestMsgNRejectsOversizedChainID(t *testing.T) {
+	oversized := new(big.Int).Lsh(big.NewInt(1), 64)
+	acA := newTestAccountOn(t, rollup.New("http://127.0.0.1:0", big.NewInt(77777), "rollup-a"))
+	acHuge := newTestAccountOn(t, rollup.New("http://127.0.0.1:0", oversized, "rollup-huge"))
+
+	_, err := CreateCrossTxRequestMsgN(t.Context(), []CrossTxLeg{
+		{Account: acA, SignedTx: []byte{0x0a}},
+		{Account: acHuge, SignedTx: []byte{0x0b}},
+	})
+	require.ErrorContains(t, err, "chain ID 18446744073709551616 of cross tx leg 1 on rollup-huge does not fit in 64 bits")
+}
+
+func TestCreateCrossTxRequestMsgGroupedKeepsOrderPerChain(t *testing.T) {
+	acA := newTestAccountOn(t, rollup.New("http://127.0.0.1:0", big.NewInt(77777), "rollup-a"))
+	acB := newTestAccountOn(t, rollup.New("http://127.0.0.1:0", big.NewInt(88888), "rollup-b"))
+
+	encoded, err := CreateCrossTxRequestMsgGrouped(t.Context(), map[*accounts.Account][][]byte{
+		acB: {{0x0b}},
+		acA: {{0x01}, {0x02}}, // e.g. approve followed by bridge send
+	})
+	require.NoError(t, err)
+
+	xtRequest, err := DecodeCrossTxRequest(encoded)
+	require.NoError(t, err)
+	txRequests := xtRequest.GetTransactions()
+	require.Len(t, txRequests, 2)
+	require.Equal(t, big.NewInt(77777).Bytes(), txRequests[0].ChainId)
+	require.Equal(t, [][]byte{{0x01}, {0x02}}, txRequests[0].Transaction)
+	require.Equal(t, big.NewInt(88888).Bytes(), txRequests[1].ChainId)
+	require.Equal(t, [][]byte{{0x0b}}, txRequests[1].Transaction)
+}
+
+func TestSendCrossTxBatchBoundsConcurrency(t *testing.T) {
+	const maxConcurrency = 2
+	var inFlight, peak atomic.Int32
+	server := rpctest.NewServer(t, func(req rpctest.Request) interface{} {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		return "0xabc"
+	})
+
+	msgs := [][]byte{{0x01}, {0x02}, {0x03}, {0x04}, {0x05}}
+	errs := SendCrossTxBatch(t.Context(), msgs, server.URL, maxConcurrency)
+	require.Len(t, errs, len(msgs))
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+	require.LessOrEqual(t, peak.Load(), int32(maxConcurrency))
+
+	errs = SendCrossTxBatch(t.Context(), msgs[:2], "http://127.0.0.1:1", maxConcurrency)
+	require.ErrorContains(t, errs[0], "cross tx request 0")
+	require.ErrorContains(t, errs[1], "cross tx request 1")
+}
+
+func TestSendCrossTxRequestMsgSendsConfiguredHeaders(t *testing.T) {
+	var authorization atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization.Store(r.Header.Get("Authorization"))
+		var req rpctest.Request
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": "0xabc"})
+	}))
+	t.Cleanup(server.Close)
+
+	chainConfigs := configs.Values.L2.ChainConfigs
+	t.Cleanup(func() { configs.Values.L2.ChainConfigs = chainConfigs })
+	configs.Values.L2.ChainConfigs = map[configs.ChainName]configs.ChainConfig{
+		"rollup-a": {ID: 1, RPCURLs: configs.RPCURLList{server.URL}, Headers: map[string]string{"Authorization": "Bearer token"}},
+	}
+
+	_, err := SendCrossTxRequestMsg(t.Context(), server.URL, []byte{0x01})
+	require.NoError(t, err)
+	require.Equal(t, "Bearer token", authorization.Load())
+
+	// a dedicated coordinator-url is not a chain endpoint and gets the coordinator-headers
+	coordinatorURL := server.URL + "/coordinator"
+	l2 := configs.Values.L2
+	t.Cleanup(func() { configs.Values.L2 = l2 })
+	configs.Values.L2.CoordinatorURL = coordinatorURL
+	configs.Values.L2.CoordinatorHeaders = map[string]string{"Authorization": "Bearer coordinator"}
+
+	_, err = SendCrossTxToCoordinator(t.Context(), "http://127.0.0.1:1", []byte{0x01})
+	require.NoError(t, err)
+	require.Equal(t, "Bearer coordinator", authorization.Load())
+}
+
+func TestCreateCrossTxRequestMsgDecodes(t *testing.T) {
+	acA := newTestAccountOn(t, rollup.New("http://127.0.0.1:0", big.NewInt(77777), "rollup-a"))
+	acB := newTestAccountOn(t, rollup.New("http://127.0.0.1:0", big.NewInt(88888), "rollup-b"))
+	details := NewTxDetails(acB.GetAddress()).Gas(21000).Fees(GWei(1), GWei(20)).Build()
+	txA, rawA, err := CreateTransactionWithNonce(t.Context(), details, acA, 0)
+	require.NoError(t, err)
+	txB, rawB, err := CreateTransactionWithNonce(t.Context(), details, acB, 0)
+	require.NoError(t, err)
+
+	encoded, err := CreateCrossTxRequestMsg(t.Context(), acA, acB, rawA, rawB)
+	require.NoError(t, err)
+
+	xtRequest, err := DecodeCrossTxRequest(encoded)
+	require.NoError(t, err)
+	txRequests := xtRequest.GetTransactions()
+	require.Len(t, txRequests, 2)
+	require.Equal(t, big.NewInt(77777).Bytes(), txRequests[0].ChainId)
+	require.Equal(t, [][]byte{rawA}, txRequests[0].Transaction)
+	require.Equal(t, big.NewInt(88888).Bytes(), txRequests[1].ChainId)
+	require.Equal(t, [][]byte{rawB}, txRequests[1].Transaction)
+
+	require.Equal(t, "chain 77777:\n\t"+txA.Hash().Hex()+"\nchain 88888:\n\t"+txB.Hash().Hex()+"\n", PrettyPrint(xtRequest))
+}
+
+func TestDecodeCrossTxRequestRejectsGarbage(t *testing.T) {
+	_, err := DecodeCrossTxRequest([]byte{0xff, 0xff})
+	require.Error(t, err)
+}
+
+func TestCreateCrossTxRequestMsgSenderID(t *testing.T) {
+	acA := newTestAccountOn(t, rollup.New("http://127.0.0.1:0", big.NewInt(77777), "rollup-a"))
+	acB := newTestAccountOn(t, rollup.New("http://127.0.0.1:0", big.NewInt(88888), "rollup-b"))
+
+	encoded, err := CreateCrossTxRequestMsg(t.Context(), acA, acB, []byte{0x0a}, []byte{0x0b})
+	require.NoError(t, err)
+	var msg rollupv1.Message
+	require.NoError(t, proto.Unmarshal(encoded, &msg))
+	require.Equal(t, "client", msg.GetSenderId())
+
+	encoded, err = CreateCrossTxRequestMsg(t.Context(), acA, acB, []byte{0x0a}, []byte{0x0b}, WithSenderID("tenant-1"))
+	require.NoError(t, err)
+	require.NoError(t, proto.Unmarshal(encoded, &msg))
+	require.Equal(t, "tenant-1", msg.GetSenderId())
+}
+
+func TestSendCrossTxRequestMsgWithOptsRetriesConnectionErrors(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			http.Error(w, "coordinator restarting", http.StatusServiceUnavailable)
+			return
+		}
+		var req rpctest.Request
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": "0xabc"})
+	}))
+	t.Cleanup(server.Close)
+
+	response, err := SendCrossTxRequestMsgWithOpts(t.Context(), server.URL, []byte{0x01},
+		CrossTxSendOpts{Timeout: time.Second, MaxRetries: 2, Backoff: time.Millisecond})
+	require.NoError(t, err)
+	require.JSONEq(t, `"0xabc"`, string(response.Result))
+	require.Equal(t, int32(2), calls.Load())
+}
+
+func TestSendCrossTxRequestMsgWithOptsDoesNotRetryRejections(t *testing.T) {
+	var calls atomic.Int32
+	server := rpctest.NewServer(t, func(req rpctest.Request) interface{} {
+		calls.Add(1)
+		return &rpctest.Error{Code: -32000, Message: "invalid cross tx"}
+	})
+
+	_, err := SendCrossTxRequestMsgWithOpts(t.Context(), server.URL, []byte{0x01},
+		CrossTxSendOpts{MaxRetries: 3, Backoff: time.Millisecond})
+	require.ErrorContains(t, err, "invalid cross tx")
+	require.Equal(t, int32(1), calls.Load())
+}
+
+func TestSendCrossTxRequestMsgWithOptsDoesNotRetryClientErrors(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		http.Error(w, "missing token", http.StatusUnauthorized)
+	}))
+	t.Cleanup(server.Close)
+
+	_, err := SendCrossTxRequestMsgWithOpts(t.Context(), server.URL, []byte{0x01},
+		CrossTxSendOpts{MaxRetries: 3, Backoff: time.Millisecond})
+	require.ErrorContains(t, err, "401 Unauthorized")
+	require.Equal(t, int32(1), calls.Load())
+}
+
+func TestSendCrossTxRequestMsgWithOptsDoesNotRetryUndecodableResponses(t *testing.T) {
+	// the coordinator accepted the request but its answer cannot be read, so resending could submit it twice
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte("accepted"))
+	}))
+	t.Cleanup(server.Close)
+
+	_, err := SendCrossTxRequestMsgWithOpts(t.Context(), server.URL, []byte{0x01},
+		CrossTxSendOpts{MaxRetries: 3, Backoff: time.Millisecond})
+	require.Error(t, err)
+	require.Equal(t, int32(1), calls.Load())
+}
+
+func TestSendCrossTxRequestMsgReturnsRejection(t *testing.T) {
+	server := rpctest.NewServer(t, func(req rpctest.Request) interface{} {
+		return &rpctest.Error{Code: -32000, Message: "cross tx rejected", Data: "leg 2 would revert"}
+	})
+
+	_, err := SendCrossTxRequestMsg(t.Context(), server.URL, []byte{0x01})
+	var rejected *CrossTxRejectedError
+	require.ErrorAs(t, err, &rejected)
+	require.Equal(t, -32000, rejected.Code)
+	require.Equal(t, "cross tx rejected", rejected.Message)
+	require.Equal(t, "leg 2 would revert", rejected.Data)
+	require.EqualError(t, err, "cross tx request rejected (code -32000): cross tx rejected (data: leg 2 would revert)")
+}
+
+func TestSendCrossTxToCoordinatorSelectsEndpoint(t *testing.T) {
+	previous := configs.Values.L2.CoordinatorURL
+	t.Cleanup(func() { configs.Values.L2.CoordinatorURL = previous })
+
+	var rollupCalls, coordinatorCalls atomic.Int32
+	rollupServer := rpctest.NewServer(t, func(req rpctest.Request) interface{} {
+		rollupCalls.Add(1)
+		return "0x01"
+	})
+	coordinatorServer := rpctest.NewServer(t, func(req rpctest.Request) interface{} {
+		coordinatorCalls.Add(1)
+		return "0x02"
+	})
+
+	configs.Values.L2.CoordinatorURL = ""
+	require.Equal(t, rollupServer.URL, CoordinatorURL(rollupServer.URL))
+	response, err := SendCrossTxToCoordinator(t.Context(), rollupServer.URL, []byte{0x01})
+	require.NoError(t, err)
+	require.JSONEq(t, `"0x01"`, string(response.Result))
+
+	configs.Values.L2.CoordinatorURL = coordinatorServer.URL
+	require.Equal(t, coordinatorServer.URL, CoordinatorURL(rollupServer.URL))
+	response, err = SendCrossTxToCoordinator(t.Context(), rollupServer.URL, []byte{0x01})
+	require.NoError(t, err)
+	require.JSONEq(t, `"0x02"`, string(response.Result))
+
+	require.Equal(t, int32(1), rollupCalls.Load())
+	require.Equal(t, int32(1), coordinatorCalls.Load())
+}
+
+func TestCrossTxRequestRoundTripThroughMockCoordinator(t *testing.T) {
+	coordinator := mock.NewCoordinator()
+	defer coordinator.Close()
+
+	acA := newTestAccountOn(t, rollup.New("http://127.0.0.1:0", big.NewInt(77777), "rollup-a"))
+	acB := newTestAccountOn(t, rollup.New("http://127.0.0.1:0", big.NewInt(88888), "rollup-b"))
+	encoded, err := CreateCrossTxRequestMsg(t.Context(), acA, acB, []byte{0x0a}, []byte{0x0b}, WithSenderID("offline"))
+	require.NoError(t, err)
+
+	response, err := SendCrossTxRequestMsg(t.Context(), coordinator.URL(), encoded)
+	require.NoError(t, err)
+	requestID, err := CrossTxRequestID(encoded)
+	require.NoError(t, err)
+	require.Equal(t, requestID, response.RequestID)
+	var result struct {
+		RequestID string `json:"requestId"`
+	}
+	require.NoError(t, json.Unmarshal(response.Result, &result))
+	require.Equal(t, requestID, result.RequestID, "the coordinator and the client derive the same request ID")
+
+	requests := coordinator.Requests()
+	require.Len(t, requests, 1)
+	require.Equal(t, "offline", requests[0].GetSenderId())
+	txs := requests[0].GetXtRequest().GetTransactions()
+	require.Len(t, txs, 2)
+	require.Equal(t, big.NewInt(77777).Bytes(), txs[0].GetChainId())
+	require.Equal(t, [][]byte{{0x0a}}, txs[0].GetTransaction())
+	require.Equal(t, big.NewInt(88888).Bytes(), txs[1].GetChainId())
+	require.Equal(t, [][]byte{{0x0b}}, txs[1].GetTransaction())
+
+	var rejected *CrossTxRejectedError
+	_, err = SendCrossTxRequestMsg(t.Context(), coordinator.URL(), []byte{0xff, 0xff})
+	require.ErrorAs(t, err, &rejected)
+	require.Len(t, coordinator.Requests(), 1)
+}
+
+func TestCreateCrossTxRequestMsgRawMatchesAccountBasedEncoding(t *testing.T) {
+	acA := newTestAccountOn(t, rollup.New("http://127.0.0.1:0", big.NewInt(77777), "rollup-a"))
+	acB := newTestAccountOn(t, rollup.New("http://127.0.0.1:0", big.NewInt(88888), "rollup-b"))
+
+	fromAccounts, err := CreateCrossTxRequestMsg(t.Context(), acA, acB, []byte{0x0a}, []byte{0x0b})
+	require.NoError(t, err)
+	raw, err := CreateCrossTxRequestMsgRaw([]RawLeg{
+		{ChainID: 77777, SignedTx: []byte{0x0a}},
+		{ChainID: 88888, SignedTx: []byte{0x0b}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, fromAccounts, raw)
+
+	_, err = CreateCrossTxRequestMsgRaw(nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,84 @@
+package transactions
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/compose-network/dome/configs"
+	"github.com/compose-network/dome/internal/accounts"
+	"github.com/compose-network/dome/internal/logger"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Mint mints amount of the configured token to ac and waits for the receipt.
+// It returns an error if the mint transaction reverts.
+func Mint(ctx context.Context, ac *accounts.Account, amount *big.Int, tokenABI abi.ABI) (*types.Transaction, *types.Receipt, error) {
+	calldata, err := tokenABI.Pack("mint", ac.GetAddress(), amount)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to pack mint calldata: %w", err)
+	}
+
+	tx, receipt, err := sendTokenTx(ctx, ac, configs.Values.L2.Contracts[configs.ContractNameToken].Address, calldata, configs.Values.GasProfile.Gas(configs.GasOpMint))
+	if err != nil {
+		return tx, receipt, fmt.Errorf("mint failed: %w", err)
+	}
+	logger.Info("Mint transaction executed successfully: %s", tx.Hash())
+	return tx, receipt, nil
+}
+
+// Approve approves spender to spend amount of the configured token on behalf of ac and waits for the receipt.
+// It returns an error if the approve transaction reverts.
+func Approve(ctx context.Context, ac *accounts.Account, spender common.Address, amount *big.Int, tokenABI abi.ABI) (*types.Transaction, *types.Receipt, error) {
+	calldata, err := tokenABI.Pack("approve", spender, amount)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to pack approve calldata: %w", err)
+	}
+
+	tx, receipt, err := sendTokenTx(ctx, ac, configs.Values.L2.Contracts[configs.ContractNameToken].Address, calldata, configs.Values.GasProfile.Gas(configs.GasOpApprove))
+	if err != nil {
+		return tx, receipt, fmt.Errorf("approve failed: %w", err)
+	}
+	logger.Info("Approve transaction executed successfully: %s", tx.Hash())
+	return tx, receipt, nil
+}
+
+// TransferToken transfers amount of token from ac to the given address and waits for the receipt.
+// It returns an error if the transfer transaction reverts.
+func TransferToken(ctx context.Context, ac *accounts.Account, token, to common.Address, amount *big.Int, tokenABI abi.ABI) (*types.Transaction, *types.Receipt, error) {
+	calldata, err := tokenABI.Pack("transfer", to, amount)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to pack transfer calldata: %w", err)
+	}
+
+	tx, receipt, err := sendTokenTx(ctx, ac, token, calldata, 0)
+	if err != nil {
+		return tx, receipt, fmt.Errorf("transfer failed: %w", err)
+	}
+	logger.Info("Transfer transaction executed successfully: %s", tx.Hash())
+	return tx, receipt, nil
+}
+
+// TransferFromToken transfers amount of token from the given owner to the given address, spending ac's allowance,
+// and waits for the receipt. It returns an error if the transferFrom transaction reverts.
+func TransferFromToken(ctx context.Context, ac *accounts.Account, token, from, to common.Address, amount *big.Int, tokenABI abi.ABI) (*types.Transaction, *types.Receipt, error) {
+	calldata, err := tokenABI.Pack("transferFrom", from, to, amount)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to pack transferFrom calldata: %w", err)
+	}
+
+	tx, receipt, err := sendTokenTx(ctx, ac, token, calldata, 0)
+	if err != nil {
+		return tx, receipt, fmt.Errorf("transferFrom failed: %w", err)
+	}
+	logger.Info("TransferFrom transaction executed successfully: %s", tx.Hash())
+	return tx, receipt, nil
+}
+
+// sendTokenTx sends calldata to the token contract from ac with the given gas limit, 0 meaning estimated,
+// and waits for a successful receipt
+func sendTokenTx(ctx context.Context, ac *accounts.Account, token common.Address, calldata []byte, gas uint64) (*types.Transaction, *types.Receipt, error) {
+	return SendAndWait(ctx, NewTxDetails(token).Gas(gas).Data(calldata).Build(), ac)
+}
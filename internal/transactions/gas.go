@@ -0,0 +1,68 @@
+package transactions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/compose-network/dome/internal/accounts"
+	"github.com/compose-network/dome/internal/logger"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// GasEstimateBufferPercent is the headroom added on top of the node's gas estimate
+var GasEstimateBufferPercent uint64 = 20
+
+// EstimateGas estimates the gas needed to execute the transaction described by details from ac.
+// The returned value includes GasEstimateBufferPercent of headroom.
+func EstimateGas(ctx context.Context, details TransactionDetails, ac *accounts.Account) (uint64, error) {
+	client, err := ac.GetRollup().Client(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	estimate, err := client.EstimateGas(ctx, toCallMsg(details, ac))
+	if err != nil {
+		if reason, ok := revertReasonFromData(err); ok {
+			return 0, fmt.Errorf("failed to estimate gas: %w (revert reason: %s)", err, reason)
+		}
+		var dataErr rpc.DataError
+		if errors.As(err, &dataErr) && dataErr.ErrorData() != nil {
+			return 0, fmt.Errorf("failed to estimate gas: %w (data: %v)", err, dataErr.ErrorData())
+		}
+		return 0, fmt.Errorf("failed to estimate gas: %w", err)
+	}
+
+	gas := estimate + estimate*GasEstimateBufferPercent/100
+	logger.Debug("Estimated gas on %s: %d (with %d%% buffer: %d)", ac.GetRollup().Name(), estimate, GasEstimateBufferPercent, gas)
+	return gas, nil
+}
+
+// toCallMsg builds the call message equivalent of details sent from ac
+func toCallMsg(details TransactionDetails, ac *accounts.Account) ethereum.CallMsg {
+	to := details.To
+	msg := ethereum.CallMsg{
+		From:       ac.GetAddress(),
+		To:         &to,
+		Gas:        details.Gas,
+		Value:      details.Value,
+		Data:       details.Data,
+		AccessList: details.AccessList,
+	}
+	if details.Legacy {
+		msg.GasPrice = details.GasPrice
+	} else {
+		msg.GasTipCap = details.GasTipCap
+		msg.GasFeeCap = details.GasFeeCap
+	}
+	return msg
+}
+
+// resolveGas returns the explicit gas limit from details, or an estimate when it is not set
+func resolveGas(ctx context.Context, details TransactionDetails, ac *accounts.Account) (uint64, error) {
+	if details.Gas != 0 {
+		return details.Gas, nil
+	}
+	return EstimateGas(ctx, details, ac)
+}
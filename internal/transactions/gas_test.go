@@ -0,0 +1,45 @@
+package transactions
+
+import (
+	"math/big"
+	"sync/atomic"
+	"testing"
+
+	"github.com/compose-network/dome/internal/rollup"
+	"github.com/compose-network/dome/internal/rpctest"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimateGas(t *testing.T) {
+	var estimateErr atomic.Pointer[rpctest.Error]
+	server := rpctest.NewServer(t, func(req rpctest.Request) interface{} {
+		switch req.Method {
+		case "eth_chainId":
+			return rpctest.ChainID
+		case "eth_estimateGas":
+			if rpcErr := estimateErr.Load(); rpcErr != nil {
+				return rpcErr
+			}
+			return "0xc350"
+		}
+		t.Errorf("unexpected method %s", req.Method)
+		return nil
+	})
+	ac := newTestAccountOn(t, rollup.New(server.URL, big.NewInt(77777), "test-rollup"))
+	details := NewTxDetails(common.HexToAddress("0x01")).Build()
+
+	// 50000 plus the 20% buffer
+	gas, err := EstimateGas(t.Context(), details, ac)
+	require.NoError(t, err)
+	require.Equal(t, uint64(60000), gas)
+
+	estimateErr.Store(&rpctest.Error{Code: 3, Message: "execution reverted", Data: insufficientBalanceRevert})
+	_, err = EstimateGas(t.Context(), details, ac)
+	require.ErrorContains(t, err, "failed to estimate gas: execution reverted (revert reason: insufficient balance)")
+
+	// custom errors cannot be decoded without the contract ABI, so the raw data is kept
+	estimateErr.Store(&rpctest.Error{Code: 3, Message: "execution reverted", Data: "0xdeadbeef"})
+	_, err = EstimateGas(t.Context(), details, ac)
+	require.ErrorContains(t, err, "(data: 0xdeadbeef)")
+}
@@ -0,0 +1,82 @@
+// Package mock provides in-process stand-ins for the services the transactions package talks to,
+// so cross tx flows can be tested without live rollups.
+package mock
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"sync"
+
+	"github.com/compose-network/dome/pkg/rollupv1"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// Coordinator is an HTTP JSON-RPC server implementing eth_sendXTransaction.
+// It decodes every submitted request and records it for assertions.
+type Coordinator struct {
+	server *httptest.Server
+	rpc    *rpc.Server
+
+	mu       sync.Mutex
+	requests []*rollupv1.Message
+}
+
+// NewCoordinator starts a mock coordinator. Close it when done.
+func NewCoordinator() *Coordinator {
+	c := &Coordinator{rpc: rpc.NewServer()}
+	if err := c.rpc.RegisterName("eth", &coordinatorService{coordinator: c}); err != nil {
+		panic(fmt.Errorf("failed to register mock coordinator service: %w", err))
+	}
+	c.server = httptest.NewServer(c.rpc)
+	return c
+}
+
+// URL is the endpoint cross tx requests are sent to
+func (c *Coordinator) URL() string {
+	return c.server.URL
+}
+
+// Requests returns the decoded messages received so far, in arrival order
+func (c *Coordinator) Requests() []*rollupv1.Message {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]*rollupv1.Message(nil), c.requests...)
+}
+
+func (c *Coordinator) Close() {
+	c.server.Close()
+	c.rpc.Stop()
+}
+
+type coordinatorService struct {
+	coordinator *Coordinator
+}
+
+// sendXTransactionResult mirrors the object form of the coordinator's eth_sendXTransaction reply
+type sendXTransactionResult struct {
+	RequestID string `json:"requestId"`
+	Accepted  bool   `json:"accepted"`
+}
+
+// SendXTransaction serves eth_sendXTransaction. The request ID is the XtID of the XTRequest, as the coordinator derives it.
+func (s *coordinatorService) SendXTransaction(payload hexutil.Bytes) (*sendXTransactionResult, error) {
+	var msg rollupv1.Message
+	if err := proto.Unmarshal(payload, &msg); err != nil {
+		return nil, fmt.Errorf("failed to decode cross tx request: %w", err)
+	}
+	if msg.GetXtRequest() == nil {
+		return nil, fmt.Errorf("message from %q carries no XTRequest", msg.GetSenderId())
+	}
+	xtID, err := msg.GetXtRequest().XtID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive XtID: %w", err)
+	}
+
+	s.coordinator.mu.Lock()
+	s.coordinator.requests = append(s.coordinator.requests, &msg)
+	s.coordinator.mu.Unlock()
+
+	return &sendXTransactionResult{RequestID: hexutil.Encode(xtID.Hash), Accepted: true}, nil
+}
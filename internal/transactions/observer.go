@@ -0,0 +1,140 @@
+package transactions
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Observer is notified about the lifecycle of every transaction sent through this package
+type Observer interface {
+	// OnSubmitted is called once the node, or the coordinator for a cross tx leg, accepted the transaction
+	OnSubmitted(txHash common.Hash)
+	// OnConfirmed is called when the transaction was found mined successfully. It may be called again for the same
+	// transaction when its receipt is waited for more than once.
+	OnConfirmed(txHash common.Hash)
+	// OnFailed is called when the transaction could not be sent, reverted or was not mined
+	OnFailed(txHash common.Hash, err error)
+}
+
+type noopObserver struct{}
+
+func (noopObserver) OnSubmitted(common.Hash)     {}
+func (noopObserver) OnConfirmed(common.Hash)     {}
+func (noopObserver) OnFailed(common.Hash, error) {}
+
+var (
+	observerMu sync.RWMutex
+	observer   Observer = noopObserver{}
+)
+
+// SetObserver installs o as the package-wide transaction observer. Passing nil restores the no-op default.
+func SetObserver(o Observer) {
+	observerMu.Lock()
+	defer observerMu.Unlock()
+
+	if o == nil {
+		o = noopObserver{}
+	}
+	observer = o
+}
+
+func currentObserver() Observer {
+	observerMu.RLock()
+	defer observerMu.RUnlock()
+	return observer
+}
+
+// LatencyObserver records the latency from submission to confirmation and counts submitted, confirmed and failed
+// transactions. Every transaction is counted once, however often its receipt is waited for.
+// It is safe for concurrent use.
+type LatencyObserver struct {
+	mu          sync.Mutex
+	now         func() time.Time // time.Now unless overridden by tests
+	submittedAt map[common.Hash]time.Time
+	settled     map[common.Hash]bool // transactions already counted as confirmed or failed
+	confirmed   int
+	failed      int
+	durations   []time.Duration
+}
+
+func (o *LatencyObserver) OnSubmitted(txHash common.Hash) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.submittedAt == nil {
+		o.submittedAt = make(map[common.Hash]time.Time)
+	}
+	if _, ok := o.submittedAt[txHash]; !ok {
+		o.submittedAt[txHash] = o.timeNow()
+	}
+}
+
+// OnConfirmed counts the transaction as confirmed. Its latency is only recorded when it was submitted through
+// this package while the observer was installed.
+func (o *LatencyObserver) OnConfirmed(txHash common.Hash) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if !o.settle(txHash) {
+		return
+	}
+	o.confirmed++
+	if submittedAt, ok := o.submittedAt[txHash]; ok {
+		o.durations = append(o.durations, o.timeNow().Sub(submittedAt))
+	}
+}
+
+func (o *LatencyObserver) OnFailed(txHash common.Hash, _ error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.settle(txHash) {
+		o.failed++
+	}
+}
+
+// settle marks txHash as settled and reports whether it was not settled before
+func (o *LatencyObserver) settle(txHash common.Hash) bool {
+	if o.settled == nil {
+		o.settled = make(map[common.Hash]bool)
+	}
+	if o.settled[txHash] {
+		return false
+	}
+	o.settled[txHash] = true
+	return true
+}
+
+func (o *LatencyObserver) timeNow() time.Time {
+	if o.now != nil {
+		return o.now()
+	}
+	return time.Now()
+}
+
+// Counts returns the number of submitted, confirmed and failed transactions seen so far
+func (o *LatencyObserver) Counts() (submitted, confirmed, failed int) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return len(o.submittedAt), o.confirmed, o.failed
+}
+
+// Percentile returns the confirmation latency below which p percent (0-100) of confirmations fall,
+// using the nearest-rank method. It returns 0 if nothing was confirmed.
+func (o *LatencyObserver) Percentile(p float64) time.Duration {
+	o.mu.Lock()
+	sorted := append([]time.Duration(nil), o.durations...)
+	o.mu.Unlock()
+
+	if len(sorted) == 0 {
+		return 0
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	rank = min(max(rank, 1), len(sorted))
+	return sorted[rank-1]
+}
@@ -0,0 +1,104 @@
+package transactions
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/compose-network/dome/internal/rollup"
+	"github.com/compose-network/dome/internal/rpctest"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatencyObserverPercentile(t *testing.T) {
+	now := time.Unix(0, 0)
+	o := &LatencyObserver{now: func() time.Time { return now }}
+	require.Zero(t, o.Percentile(50))
+
+	for i := 1; i <= 10; i++ {
+		o.OnSubmitted(common.BigToHash(big.NewInt(int64(i))))
+	}
+	for i := 1; i <= 10; i++ {
+		now = now.Add(time.Second)
+		o.OnConfirmed(common.BigToHash(big.NewInt(int64(i))))
+	}
+	o.OnFailed(common.HexToHash("0xff"), nil)
+
+	require.Equal(t, 5*time.Second, o.Percentile(50))
+	require.Equal(t, 10*time.Second, o.Percentile(95))
+	require.Equal(t, time.Second, o.Percentile(0))
+	submitted, confirmed, failed := o.Counts()
+	require.Equal(t, []int{10, 10, 1}, []int{submitted, confirmed, failed})
+}
+
+func TestLatencyObserverCountsEachTxOnce(t *testing.T) {
+	now := time.Unix(0, 0)
+	o := &LatencyObserver{now: func() time.Time { return now }}
+	confirmedTx, failedTx, unobservedTx := common.HexToHash("0x01"), common.HexToHash("0x02"), common.HexToHash("0x03")
+
+	o.OnSubmitted(confirmedTx)
+	now = now.Add(time.Second)
+	o.OnSubmitted(confirmedTx) // resubmitting keeps the first submit time
+	now = now.Add(time.Second)
+	o.OnConfirmed(confirmedTx)
+	now = now.Add(time.Minute)
+	o.OnConfirmed(confirmedTx) // waited for again later
+
+	o.OnFailed(failedTx, nil)
+	o.OnFailed(failedTx, nil)
+	o.OnConfirmed(unobservedTx) // submitted before the observer was installed
+
+	submitted, confirmed, failed := o.Counts()
+	require.Equal(t, []int{1, 2, 1}, []int{submitted, confirmed, failed})
+	require.Equal(t, 2*time.Second, o.Percentile(100))
+}
+
+func TestSendTransactionNotifiesObserver(t *testing.T) {
+	server := rpctest.NewServer(t, func(req rpctest.Request) interface{} {
+		switch req.Method {
+		case "eth_chainId":
+			return "0x12fd1"
+		case "eth_sendRawTransaction":
+			return common.Hash{}.Hex()
+		}
+		t.Errorf("unexpected method %s", req.Method)
+		return nil
+	})
+	ac := newTestAccountOn(t, rollup.New(server.URL, big.NewInt(77777), "rollup-a"))
+	tx, _, err := CreateTransactionWithNonce(t.Context(), NewTxDetails(ac.GetAddress()).Gas(21000).Fees(GWei(1), GWei(20)).Build(), ac, 0)
+	require.NoError(t, err)
+
+	o := &LatencyObserver{}
+	SetObserver(o)
+	t.Cleanup(func() { SetObserver(nil) })
+
+	_, err = SendTransaction(t.Context(), tx, ac.GetRollup())
+	require.NoError(t, err)
+	submitted, _, _ := o.Counts()
+	require.Equal(t, 1, submitted)
+}
+
+func TestSendCrossTxRequestMsgNotifiesObserverOfLegs(t *testing.T) {
+	server := rpctest.NewServer(t, func(req rpctest.Request) interface{} {
+		return "0x01"
+	})
+	encoded, err := CreateCrossTxRequestMsgRaw([]RawLeg{
+		{ChainID: 77777, SignedTx: []byte{0x0a}},
+		{ChainID: 88888, SignedTx: []byte{0x0b}},
+	})
+	require.NoError(t, err)
+
+	o := &LatencyObserver{}
+	SetObserver(o)
+	t.Cleanup(func() { SetObserver(nil) })
+
+	_, err = SendCrossTxRequestMsg(t.Context(), server.URL, encoded)
+	require.NoError(t, err)
+	o.OnConfirmed(crypto.Keccak256Hash([]byte{0x0a}))
+	submitted, confirmed, _ := o.Counts()
+	require.Equal(t, 2, submitted)
+	require.Equal(t, 1, confirmed)
+	require.Len(t, o.durations, 1)
+}
@@ -0,0 +1,83 @@
+package transactions
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/compose-network/dome/internal/accounts"
+	"github.com/compose-network/dome/internal/logger"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// minReplacementBumpPercent is the fee increase nodes require before accepting a replacement transaction
+const minReplacementBumpPercent = 110
+
+// minReplacementTip is the lowest tip (or legacy gas price) a replacement is sent with, since scaling a zero tip
+// leaves it at zero
+var minReplacementTip = GWei(1)
+
+// BumpFee re-signs original with the same nonce, recipient, value, gas and data but with its fees scaled
+// by multiplierPercent (at least 110%), then submits the replacement. The new transaction is returned so
+// callers can wait on it.
+func BumpFee(ctx context.Context, original *types.Transaction, ac *accounts.Account, multiplierPercent int) (*types.Transaction, error) {
+	replacement, err := bumpedTransaction(ctx, original, ac, multiplierPercent)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := SendTransaction(ctx, replacement, ac.GetRollup()); err != nil {
+		return nil, fmt.Errorf("failed to send replacement for %s: %w", original.Hash().Hex(), err)
+	}
+	logger.Info("Replaced transaction %s with %s on %s (nonce %d)", original.Hash().Hex(), replacement.Hash().Hex(), ac.GetRollup().Name(), replacement.Nonce())
+	return replacement, nil
+}
+
+// bumpedTransaction builds and signs the replacement for original without sending it
+func bumpedTransaction(ctx context.Context, original *types.Transaction, ac *accounts.Account, multiplierPercent int) (*types.Transaction, error) {
+	if original.To() == nil {
+		return nil, fmt.Errorf("cannot replace contract creation transaction %s", original.Hash().Hex())
+	}
+	if multiplierPercent < minReplacementBumpPercent {
+		multiplierPercent = minReplacementBumpPercent
+	}
+
+	details := TransactionDetails{
+		To:         *original.To(),
+		Value:      original.Value(),
+		Data:       original.Data(),
+		Gas:        original.Gas(),
+		AccessList: original.AccessList(),
+	}
+	switch original.Type() {
+	case types.LegacyTxType:
+		details.Legacy = true
+		details.GasPrice = bigMax(scaleFee(original.GasPrice(), multiplierPercent), minReplacementTip)
+	case types.DynamicFeeTxType:
+		details.GasTipCap = bigMax(scaleFee(original.GasTipCap(), multiplierPercent), minReplacementTip)
+		details.GasFeeCap = bigMax(scaleFee(original.GasFeeCap(), multiplierPercent), details.GasTipCap)
+	default:
+		return nil, fmt.Errorf("cannot replace transaction %s of type %d", original.Hash().Hex(), original.Type())
+	}
+
+	replacement, _, err := CreateTransactionWithNonce(ctx, details, ac, original.Nonce())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create replacement for %s: %w", original.Hash().Hex(), err)
+	}
+	return replacement, nil
+}
+
+// scaleFee returns fee * percent / 100, rounded up so small fees still clear the replacement threshold
+func scaleFee(fee *big.Int, percent int) *big.Int {
+	scaled := new(big.Int).Mul(fee, big.NewInt(int64(percent)))
+	scaled.Add(scaled, big.NewInt(99))
+	return scaled.Div(scaled, big.NewInt(100))
+}
+
+// bigMax returns the larger of a and b
+func bigMax(a, b *big.Int) *big.Int {
+	if a.Cmp(b) < 0 {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,73 @@
+package transactions
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBumpedTransactionKeepsNonceAndRaisesFees(t *testing.T) {
+	ac := newTestAccount(t)
+	details := TransactionDetails{
+		To:        common.HexToAddress("0x01"),
+		Value:     big.NewInt(1),
+		Data:      []byte{0xab},
+		Gas:       21000,
+		GasTipCap: GWei(5),
+		GasFeeCap: GWei(1000),
+	}
+	original, _, err := CreateTransactionWithNonce(t.Context(), details, ac, 3)
+	require.NoError(t, err)
+
+	// a multiplier below the replacement rule is raised to 110%
+	replacement, err := bumpedTransaction(t.Context(), original, ac, 101)
+	require.NoError(t, err)
+	require.Equal(t, original.Nonce(), replacement.Nonce())
+	require.Equal(t, original.To(), replacement.To())
+	require.Equal(t, original.Data(), replacement.Data())
+	require.Equal(t, original.Gas(), replacement.Gas())
+	require.Equal(t, big.NewInt(5_500_000_000), replacement.GasTipCap())
+	require.Equal(t, GWei(1100), replacement.GasFeeCap())
+	require.NotEqual(t, original.Hash(), replacement.Hash())
+
+	replacement, err = bumpedTransaction(t.Context(), original, ac, 150)
+	require.NoError(t, err)
+	require.Equal(t, GWei(1500), replacement.GasFeeCap())
+
+	details.Legacy = true
+	details.GasPrice = GWei(200)
+	legacy, _, err := CreateTransactionWithNonce(t.Context(), details, ac, 4)
+	require.NoError(t, err)
+	replacement, err = bumpedTransaction(t.Context(), legacy, ac, 125)
+	require.NoError(t, err)
+	require.Equal(t, legacy.Nonce(), replacement.Nonce())
+	require.Equal(t, GWei(250), replacement.GasPrice())
+}
+
+func TestBumpedTransactionRaisesZeroTipToFloor(t *testing.T) {
+	ac := newTestAccount(t)
+	details := TransactionDetails{
+		To:        common.HexToAddress("0x01"),
+		Value:     big.NewInt(0),
+		Gas:       21000,
+		GasTipCap: big.NewInt(0),
+		GasFeeCap: big.NewInt(500_000_000),
+	}
+	original, _, err := CreateTransactionWithNonce(t.Context(), details, ac, 3)
+	require.NoError(t, err)
+
+	replacement, err := bumpedTransaction(t.Context(), original, ac, 110)
+	require.NoError(t, err)
+	require.Equal(t, minReplacementTip, replacement.GasTipCap())
+	require.Equal(t, minReplacementTip, replacement.GasFeeCap(), "the fee cap is raised to cover the tip")
+
+	details.Legacy = true
+	details.GasPrice = big.NewInt(0)
+	legacy, _, err := CreateTransactionWithNonce(t.Context(), details, ac, 4)
+	require.NoError(t, err)
+	replacement, err = bumpedTransaction(t.Context(), legacy, ac, 110)
+	require.NoError(t, err)
+	require.Equal(t, minReplacementTip, replacement.GasPrice())
+}
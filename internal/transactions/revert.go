@@ -0,0 +1,115 @@
+package transactions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/compose-network/dome/internal/rollup"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// GetRevertReason re-executes tx as a call at blockNumber and decodes the revert reason.
+// It returns an empty string if the call does not revert, and the node error message if no revert data is returned.
+func GetRevertReason(ctx context.Context, tx *types.Transaction, rollup *rollup.Rollup, blockNumber *big.Int) (string, error) {
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return "", fmt.Errorf("failed to recover sender of %s: %w", tx.Hash().Hex(), err)
+	}
+
+	client, err := rollup.Client(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	msg := ethereum.CallMsg{
+		From:      from,
+		To:        tx.To(),
+		Gas:       tx.Gas(),
+		GasTipCap: tx.GasTipCap(),
+		GasFeeCap: tx.GasFeeCap(),
+		Value:     tx.Value(),
+		Data:      tx.Data(),
+	}
+
+	_, err = client.CallContract(ctx, msg, blockNumber)
+	if err == nil {
+		return "", nil
+	}
+	return revertReasonFromError(err), nil
+}
+
+// GetReceiptRevertReason replays tx on the state it was executed on, the parent of the block in receipt, and decodes
+// the revert reason. Replaying at the receipt's own block would read the state after tx ran.
+func GetReceiptRevertReason(ctx context.Context, tx *types.Transaction, rollup *rollup.Rollup, receipt *types.Receipt) (string, error) {
+	var parent *big.Int
+	if receipt.BlockNumber != nil && receipt.BlockNumber.Sign() > 0 {
+		parent = new(big.Int).Sub(receipt.BlockNumber, common.Big1)
+	}
+	return GetRevertReason(ctx, tx, rollup, parent)
+}
+
+// revertReasonFromError decodes the revert data attached to a call error, falling back to the error message
+func revertReasonFromError(err error) string {
+	data, ok := revertData(err)
+	if !ok {
+		return err.Error()
+	}
+	return DecodeRevertReason(data)
+}
+
+// isRevertError reports whether err is the node reporting a reverted execution, as opposed to a transport or RPC failure
+func isRevertError(err error) bool {
+	if _, ok := revertData(err); ok {
+		return true
+	}
+	var rpcErr rpc.Error
+	return errors.As(err, &rpcErr) && strings.Contains(rpcErr.Error(), "execution reverted")
+}
+
+// revertReasonFromData decodes the Error(string) or Panic(uint256) revert data attached to err, if there is any
+func revertReasonFromData(err error) (string, bool) {
+	data, ok := revertData(err)
+	if !ok {
+		return "", false
+	}
+	reason, unpackErr := abi.UnpackRevert(data)
+	if unpackErr != nil {
+		return "", false
+	}
+	return reason, true
+}
+
+// revertData returns the hex encoded data attached to a JSON-RPC error, as nodes return it for reverts
+func revertData(err error) ([]byte, bool) {
+	var dataErr rpc.DataError
+	if !errors.As(err, &dataErr) {
+		return nil, false
+	}
+	hexData, ok := dataErr.ErrorData().(string)
+	if !ok {
+		return nil, false
+	}
+	data, decodeErr := hexutil.Decode(hexData)
+	if decodeErr != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+// DecodeRevertReason decodes ABI encoded revert data produced by Error(string) or Panic(uint256).
+// Unknown revert data is returned hex encoded.
+func DecodeRevertReason(data []byte) string {
+	reason, err := abi.UnpackRevert(data)
+	if err != nil {
+		return hexutil.Encode(data)
+	}
+	return reason
+}
@@ -0,0 +1,69 @@
+package transactions
+
+import (
+	"math/big"
+	"sync/atomic"
+	"testing"
+
+	"github.com/compose-network/dome/internal/rollup"
+	"github.com/compose-network/dome/internal/rpctest"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeRevertReason(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{
+			name: "error string",
+			data: "0x08c379a0" +
+				"0000000000000000000000000000000000000000000000000000000000000020" +
+				"0000000000000000000000000000000000000000000000000000000000000012" +
+				"696e73756666696369656e742066756e64730000000000000000000000000000",
+			want: "insufficient funds",
+		},
+		{
+			name: "panic code",
+			data: "0x4e487b71" +
+				"0000000000000000000000000000000000000000000000000000000000000011",
+			want: "arithmetic underflow or overflow",
+		},
+		{
+			name: "custom error",
+			data: "0xdeadbeef",
+			want: "0xdeadbeef",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, DecodeRevertReason(hexutil.MustDecode(tt.data)))
+		})
+	}
+}
+
+func TestGetReceiptRevertReasonReplaysOnParentBlock(t *testing.T) {
+	var callBlock atomic.Value
+	server := rpctest.NewServer(t, func(req rpctest.Request) interface{} {
+		if req.Method == "eth_chainId" {
+			return rpctest.ChainID
+		}
+		assert.Equal(t, "eth_call", req.Method)
+		callBlock.Store(string(req.Params[1]))
+		return &rpctest.Error{Code: 3, Message: "execution reverted", Data: insufficientBalanceRevert}
+	})
+	ac := newTestAccountOn(t, rollup.New(server.URL, big.NewInt(77777), "test-rollup"))
+	tx, _, err := CreateTransactionWithNonce(t.Context(), NewTxDetails(common.HexToAddress("0x01")).Gas(21000).Fees(GWei(1), GWei(20)).Build(), ac, 0)
+	require.NoError(t, err)
+
+	reason, err := GetReceiptRevertReason(t.Context(), tx, ac.GetRollup(), &types.Receipt{Status: types.ReceiptStatusFailed, BlockNumber: big.NewInt(10)})
+	require.NoError(t, err)
+	require.Equal(t, "insufficient balance", reason)
+	require.Equal(t, `"0x9"`, callBlock.Load(), "the tx is replayed on its parent block's state")
+}
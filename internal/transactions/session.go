@@ -0,0 +1,44 @@
+package transactions
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+)
+
+// ErrSessionIDReused is returned when a session ID is claimed twice in the same registry
+var ErrSessionIDReused = errors.New("session ID already claimed")
+
+// Sessions is the process-wide registry used by the WithClaimedSessionID and WithForcedSessionID options
+var Sessions = NewSessionRegistry()
+
+// SessionRegistry records the session IDs used in this process so that accidental reuse is caught early.
+// It is safe for concurrent use.
+type SessionRegistry struct {
+	mu      sync.Mutex
+	claimed map[string]struct{}
+}
+
+func NewSessionRegistry() *SessionRegistry {
+	return &SessionRegistry{claimed: make(map[string]struct{})}
+}
+
+// Claim records id and returns ErrSessionIDReused if it was already claimed
+func (r *SessionRegistry) Claim(id *big.Int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := id.String()
+	if _, ok := r.claimed[key]; ok {
+		return fmt.Errorf("%w: %s", ErrSessionIDReused, key)
+	}
+	r.claimed[key] = struct{}{}
+	return nil
+}
+
+// ForceClaim records id even if it was already claimed. Use it for tests that replay a session on purpose.
+func (r *SessionRegistry) ForceClaim(id *big.Int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.claimed[id.String()] = struct{}{}
+}
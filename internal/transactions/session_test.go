@@ -0,0 +1,33 @@
+package transactions
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/compose-network/dome/internal/rollup"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionRegistryRejectsReuse(t *testing.T) {
+	registry := NewSessionRegistry()
+	id := big.NewInt(42)
+
+	require.NoError(t, registry.Claim(id))
+	require.ErrorIs(t, registry.Claim(big.NewInt(42)), ErrSessionIDReused)
+	require.NoError(t, registry.Claim(big.NewInt(43)))
+
+	registry.ForceClaim(id)
+}
+
+func TestCreateCrossTxRequestMsgClaimsSessionID(t *testing.T) {
+	acA := newTestAccountOn(t, rollup.New("http://127.0.0.1:0", big.NewInt(77777), "rollup-a"))
+	acB := newTestAccountOn(t, rollup.New("http://127.0.0.1:0", big.NewInt(88888), "rollup-b"))
+	sessionID := GenerateRandomSessionID()
+
+	_, err := CreateCrossTxRequestMsg(t.Context(), acA, acB, []byte{0x0a}, []byte{0x0b}, WithClaimedSessionID(sessionID))
+	require.NoError(t, err)
+	_, err = CreateCrossTxRequestMsg(t.Context(), acA, acB, []byte{0x0a}, []byte{0x0b}, WithClaimedSessionID(sessionID))
+	require.ErrorIs(t, err, ErrSessionIDReused)
+	_, err = CreateCrossTxRequestMsg(t.Context(), acA, acB, []byte{0x0a}, []byte{0x0b}, WithForcedSessionID(sessionID))
+	require.NoError(t, err)
+}
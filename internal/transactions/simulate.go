@@ -0,0 +1,27 @@
+package transactions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/compose-network/dome/internal/accounts"
+)
+
+// SimulateTransaction executes the transaction described by details from ac as an eth_call against the latest block.
+// It returns the call's return data, or an error carrying the decoded revert reason if it would revert.
+// No transaction is signed or sent, so no nonce is consumed.
+func SimulateTransaction(ctx context.Context, details TransactionDetails, ac *accounts.Account) ([]byte, error) {
+	client, err := ac.GetRollup().Client(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := client.CallContract(ctx, toCallMsg(details, ac), nil)
+	if err != nil {
+		if !isRevertError(err) {
+			return nil, fmt.Errorf("failed to simulate transaction on %s: %w", ac.GetRollup().Name(), err)
+		}
+		return nil, fmt.Errorf("simulation on %s reverted: %s: %w", ac.GetRollup().Name(), revertReasonFromError(err), err)
+	}
+	return data, nil
+}
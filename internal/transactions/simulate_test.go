@@ -0,0 +1,65 @@
+package transactions
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/compose-network/dome/internal/rollup"
+	"github.com/compose-network/dome/internal/rpctest"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+// Error("insufficient balance") revert data
+const insufficientBalanceRevert = "0x08c379a0" +
+	"0000000000000000000000000000000000000000000000000000000000000020" +
+	"0000000000000000000000000000000000000000000000000000000000000014" +
+	"696e73756666696369656e742062616c616e6365000000000000000000000000"
+
+func TestSimulateTransaction(t *testing.T) {
+	server := rpctest.NewServer(t, func(req rpctest.Request) interface{} {
+		switch req.Method {
+		case "eth_chainId":
+			return rpctest.ChainID
+		case "eth_call":
+			var msg struct {
+				Input string `json:"input"`
+			}
+			require.NoError(t, json.Unmarshal(req.Params[0], &msg))
+			switch msg.Input {
+			case "0x01":
+				return "0x2a"
+			case "0x03":
+				return &rpctest.Error{Code: -32000, Message: "header not found"}
+			}
+			return &rpctest.Error{Code: 3, Message: "execution reverted", Data: insufficientBalanceRevert}
+		}
+		t.Errorf("unexpected method %s", req.Method)
+		return nil
+	})
+
+	ac := newTestAccountOn(t, rollup.New(server.URL, big.NewInt(77777), "test-rollup"))
+	details := TransactionDetails{To: common.HexToAddress("0x01"), Value: big.NewInt(0)}
+
+	details.Data = []byte{0x01}
+	data, err := SimulateTransaction(t.Context(), details, ac)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x2a}, data)
+
+	details.Data = []byte{0x02}
+	_, err = SimulateTransaction(t.Context(), details, ac)
+	require.ErrorContains(t, err, "simulation on test-rollup reverted: insufficient balance")
+
+	details.Data = []byte{0x03}
+	_, err = SimulateTransaction(t.Context(), details, ac)
+	require.ErrorContains(t, err, "failed to simulate transaction on test-rollup: header not found")
+
+	// a node that goes away after the client is dialed fails the call itself
+	_, err = ac.GetRollup().Client(t.Context())
+	require.NoError(t, err)
+	server.Close()
+	_, err = SimulateTransaction(t.Context(), details, ac)
+	require.ErrorContains(t, err, "failed to simulate transaction on test-rollup")
+	require.NotContains(t, err.Error(), "reverted")
+}
@@ -0,0 +1,79 @@
+package transactions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/compose-network/dome/internal/rollup"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// ErrDebugUnavailable is returned by TraceTransaction when the node does not expose the debug namespace
+var ErrDebugUnavailable = errors.New("debug namespace not available")
+
+// methodNotFoundCode is the JSON-RPC error code for unknown or disabled methods
+const methodNotFoundCode = -32601
+
+// TraceTransaction returns the callTracer trace of txHash on rollup as returned by debug_traceTransaction
+func TraceTransaction(ctx context.Context, txHash common.Hash, rollup *rollup.Rollup) (json.RawMessage, error) {
+	client, err := rollup.Client(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var trace json.RawMessage
+	err = client.Client().CallContext(ctx, &trace, "debug_traceTransaction", txHash, map[string]interface{}{"tracer": "callTracer"})
+	if err != nil {
+		var rpcErr rpc.Error
+		if errors.As(err, &rpcErr) && rpcErr.ErrorCode() == methodNotFoundCode {
+			return nil, fmt.Errorf("failed to trace %s on %s: %w: %w", txHash.Hex(), rollup.Name(), ErrDebugUnavailable, err)
+		}
+		return nil, fmt.Errorf("failed to trace %s on %s: %w", txHash.Hex(), rollup.Name(), err)
+	}
+	return trace, nil
+}
+
+// callFrame is the part of a callTracer frame PrettyTrace prints
+type callFrame struct {
+	Type         string         `json:"type"`
+	To           common.Address `json:"to"`
+	GasUsed      hexutil.Uint64 `json:"gasUsed"`
+	Error        string         `json:"error"`
+	RevertReason string         `json:"revertReason"`
+	Calls        []callFrame    `json:"calls"`
+}
+
+/*
+PrettyTrace flattens a callTracer trace into one line per call, indented by call depth, e.g.
+
+	CALL 0x1111111111111111111111111111111111111111 (gas used 52000) → revert(insufficient allowance)
+	  STATICCALL 0x3333333333333333333333333333333333333333 (gas used 2400) → ok
+*/
+func PrettyTrace(trace json.RawMessage) (string, error) {
+	var root callFrame
+	if err := json.Unmarshal(trace, &root); err != nil {
+		return "", fmt.Errorf("failed to decode call trace: %w", err)
+	}
+	var b strings.Builder
+	writeCallFrame(&b, root, 0)
+	return b.String(), nil
+}
+
+func writeCallFrame(b *strings.Builder, frame callFrame, depth int) {
+	outcome := "ok"
+	switch {
+	case frame.RevertReason != "":
+		outcome = fmt.Sprintf("revert(%s)", frame.RevertReason)
+	case frame.Error != "":
+		outcome = fmt.Sprintf("revert(%s)", frame.Error)
+	}
+	fmt.Fprintf(b, "%s%s %s (gas used %d) → %s\n", strings.Repeat("  ", depth), frame.Type, frame.To.Hex(), uint64(frame.GasUsed), outcome)
+	for _, call := range frame.Calls {
+		writeCallFrame(b, call, depth+1)
+	}
+}
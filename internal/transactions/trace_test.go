@@ -0,0 +1,47 @@
+package transactions
+
+import (
+	"encoding/json"
+	"math/big"
+	"sync/atomic"
+	"testing"
+
+	"github.com/compose-network/dome/internal/rollup"
+	"github.com/compose-network/dome/internal/rpctest"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const bridgeReceiveTrace = `{
+	"type": "CALL", "to": "0x1111111111111111111111111111111111111111", "gasUsed": "0xcb20",
+	"error": "execution reverted", "revertReason": "insufficient allowance",
+	"calls": [{"type": "STATICCALL", "to": "0x3333333333333333333333333333333333333333", "gasUsed": "0x960"}]
+}`
+
+func TestTraceTransaction(t *testing.T) {
+	var debugDisabled atomic.Bool
+	server := rpctest.NewServer(t, func(req rpctest.Request) interface{} {
+		switch {
+		case req.Method == "eth_chainId":
+			return rpctest.ChainID
+		case req.Method == "debug_traceTransaction" && !debugDisabled.Load():
+			assert.JSONEq(t, `{"tracer":"callTracer"}`, string(req.Params[1]))
+			return json.RawMessage(bridgeReceiveTrace)
+		}
+		return &rpctest.Error{Code: -32601, Message: "the method " + req.Method + " does not exist/is not available"}
+	})
+	r := rollup.New(server.URL, big.NewInt(77777), "test-rollup")
+	defer r.Close()
+
+	trace, err := TraceTransaction(t.Context(), common.HexToHash("0x01"), r)
+	require.NoError(t, err)
+	pretty, err := PrettyTrace(trace)
+	require.NoError(t, err)
+	require.Equal(t, "CALL 0x1111111111111111111111111111111111111111 (gas used 52000) → revert(insufficient allowance)\n"+
+		"  STATICCALL 0x3333333333333333333333333333333333333333 (gas used 2400) → ok\n", pretty)
+
+	debugDisabled.Store(true)
+	_, err = TraceTransaction(t.Context(), common.HexToHash("0x01"), r)
+	require.ErrorIs(t, err, ErrDebugUnavailable)
+}
@@ -6,24 +6,42 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/compose-network/dome/configs"
 	"github.com/compose-network/dome/internal/accounts"
 	"github.com/compose-network/dome/internal/logger"
 	"github.com/compose-network/dome/internal/rollup"
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	// ErrReceiptNotFound is returned by GetTransactionDetails when the transaction never reaches the node
+	ErrReceiptNotFound = errors.New("transaction receipt not found")
+	// ErrContextCancelled is returned by GetTransactionDetails when ctx is done before the transaction is mined
+	ErrContextCancelled = errors.New("context cancelled while waiting for transaction")
 )
 
 type TransactionDetails struct {
-	To        common.Address
-	Value     *big.Int
-	Data      []byte
+	To    common.Address
+	Value *big.Int
+	Data  []byte
+	// GasTipCap and GasFeeCap are filled from Rollup.SuggestFees when nil
 	GasTipCap *big.Int
 	GasFeeCap *big.Int
-	Gas       uint64
+	// Gas is the gas limit. When zero, it is estimated via EstimateGas.
+	Gas uint64
+	// AccessList is the optional EIP-2930 access list. Use AccessListFor to generate one.
+	AccessList types.AccessList
+	// Legacy builds a pre-EIP-1559 transaction priced with GasPrice instead of GasTipCap/GasFeeCap.
+	// GasPrice is filled from Rollup.SuggestGasPrice when nil.
+	Legacy   bool
+	GasPrice *big.Int
 }
 
 func CreateTransaction(ctx context.Context, tx TransactionDetails, ac *accounts.Account) (*types.Transaction, []byte, error) {
@@ -33,91 +51,198 @@ func CreateTransaction(ctx context.Context, tx TransactionDetails, ac *accounts.
 	}
 	logger.Info("Creating transaction on %s with nonce: %d", ac.GetRollup().Name(), nonce)
 
+	return signTransaction(ctx, tx, ac, nonce)
+}
+
+func CreateTransactionWithNonce(ctx context.Context, tx TransactionDetails, ac *accounts.Account, nonce uint64) (*types.Transaction, []byte, error) {
+	logger.Info("Creating transaction with nonce: %d", nonce)
+
+	return signTransaction(ctx, tx, ac, nonce)
+}
+
+// signTransaction builds the transaction described by tx with the given nonce and signs it with ac's key.
+// It returns the signed transaction and its binary encoding.
+func signTransaction(ctx context.Context, tx TransactionDetails, ac *accounts.Account, nonce uint64) (*types.Transaction, []byte, error) {
 	privateKey := ac.GetPrivateKey()
 	if privateKey == nil {
 		return nil, nil, fmt.Errorf("private key is nil")
 	}
 	logger.Info("Private key loaded successfully on %s for account: %s", ac.GetRollup().Name(), ac.GetAddress())
 
-	txData := &types.DynamicFeeTx{
-		ChainID:    ac.GetRollup().ChainID(),
-		Nonce:      nonce,
-		To:         &tx.To,
-		Value:      tx.Value,
-		Gas:        tx.Gas,
-		GasTipCap:  tx.GasTipCap,
-		GasFeeCap:  tx.GasFeeCap,
-		AccessList: nil,
-		Data:       tx.Data,
+	if !tx.Legacy && (tx.GasTipCap == nil || tx.GasFeeCap == nil) {
+		tipCap, feeCap, err := ac.GetRollup().SuggestFees(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		if tx.GasTipCap == nil {
+			tx.GasTipCap = tipCap
+		}
+		if tx.GasFeeCap == nil {
+			tx.GasFeeCap = feeCap
+		}
 	}
 
-	transaction := types.NewTx(txData)
-	signedTransaction, err := types.SignTx(transaction, types.NewLondonSigner(ac.GetRollup().ChainID()), privateKey)
-	if err != nil {
-		logger.Error("failed to sign transaction: %w", err)
-		return nil, nil, fmt.Errorf("failed to sign transaction: %w", err)
+	if tx.Legacy && tx.GasPrice == nil {
+		gasPrice, err := ac.GetRollup().SuggestGasPrice(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		tx.GasPrice = gasPrice
 	}
-	logger.Info("Transaction signed successfully: %s", signedTransaction.Hash())
 
-	marshaledTx, err := signedTransaction.MarshalBinary()
+	gas, err := resolveGas(ctx, tx, ac)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to marshal transaction: %w", err)
+		return nil, nil, err
 	}
-	return signedTransaction, marshaledTx, nil
-}
 
-func CreateTransactionWithNonce(ctx context.Context, tx TransactionDetails, ac *accounts.Account, nonce uint64) (*types.Transaction, []byte, error) {
-	logger.Info("Creating transaction with nonce: %d", nonce)
-
-	privateKey := ac.GetPrivateKey()
-	if privateKey == nil {
-		return nil, nil, fmt.Errorf("private key is nil")
-	}
-	logger.Info("Private key loaded successfully on %s for account: %s", ac.GetRollup().Name(), ac.GetAddress())
-
-	txData := &types.DynamicFeeTx{
-		ChainID:    ac.GetRollup().ChainID(),
-		Nonce:      nonce, // use the nonce provided
-		To:         &tx.To,
-		Value:      tx.Value,
-		Gas:        tx.Gas,
-		GasTipCap:  tx.GasTipCap,
-		GasFeeCap:  tx.GasFeeCap,
-		AccessList: nil,
-		Data:       tx.Data,
+	var (
+		txData types.TxData
+		signer types.Signer
+	)
+	if tx.Legacy {
+		if tx.AccessList != nil {
+			return nil, nil, fmt.Errorf("access lists are not supported by legacy transactions")
+		}
+		txData = &types.LegacyTx{
+			Nonce:    nonce,
+			GasPrice: tx.GasPrice,
+			Gas:      gas,
+			To:       &tx.To,
+			Value:    tx.Value,
+			Data:     tx.Data,
+		}
+		signer = types.NewEIP155Signer(ac.GetRollup().ChainID())
+	} else {
+		txData = &types.DynamicFeeTx{
+			ChainID:    ac.GetRollup().ChainID(),
+			Nonce:      nonce,
+			To:         &tx.To,
+			Value:      tx.Value,
+			Gas:        gas,
+			GasTipCap:  tx.GasTipCap,
+			GasFeeCap:  tx.GasFeeCap,
+			AccessList: tx.AccessList,
+			Data:       tx.Data,
+		}
+		signer = types.NewLondonSigner(ac.GetRollup().ChainID())
 	}
 
 	transaction := types.NewTx(txData)
-	signedTransaction, err := types.SignTx(transaction, types.NewLondonSigner(ac.GetRollup().ChainID()), privateKey)
+	signedTransaction, err := types.SignTx(transaction, signer, privateKey)
 	if err != nil {
-		logger.Error("failed to sign transaction: %w", err)
+		logger.Error("failed to sign transaction: %v", err)
 		return nil, nil, fmt.Errorf("failed to sign transaction: %w", err)
 	}
 	logger.Info("Transaction signed successfully: %s", signedTransaction.Hash())
 
-	marshaledTx, err := signedTransaction.MarshalBinary()
+	marshaledTx, err := RawBytes(signedTransaction)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to marshal transaction: %w", err)
+		return nil, nil, err
 	}
 	return signedTransaction, marshaledTx, nil
 }
 
-func SendTransaction(ctx context.Context, tx *types.Transaction, rpcURL string) (common.Hash, error) {
-	client, err := ethclient.DialContext(ctx, rpcURL)
+// RawBytes returns the binary encoding of tx, as sent to the node and embedded in cross tx requests
+func RawBytes(tx *types.Transaction) ([]byte, error) {
+	raw, err := tx.MarshalBinary()
 	if err != nil {
-		return common.Hash{}, fmt.Errorf("failed to connect to RPC URL %s: %w", rpcURL, err)
+		return nil, fmt.Errorf("failed to marshal transaction %s: %w", tx.Hash().Hex(), err)
+	}
+	return raw, nil
+}
+
+// ValidateTxForRollup checks that tx was signed for the chain ID of r
+func ValidateTxForRollup(tx *types.Transaction, r *rollup.Rollup) error {
+	if tx.ChainId().Cmp(r.ChainID()) != 0 {
+		return fmt.Errorf("transaction %s is signed for chain ID %s but is being sent to %s with chain ID %s", tx.Hash().Hex(), tx.ChainId(), r.Name(), r.ChainID())
+	}
+	return nil
+}
+
+func SendTransaction(ctx context.Context, tx *types.Transaction, rollup *rollup.Rollup) (common.Hash, error) {
+	if err := ValidateTxForRollup(tx, rollup); err != nil {
+		return common.Hash{}, err
+	}
+
+	client, err := rollup.Client(ctx)
+	if err != nil {
+		return common.Hash{}, err
 	}
-	defer client.Close()
 
 	err = client.SendTransaction(ctx, tx)
 	if err != nil {
 		logger.Error("failed to send transaction: %v", err)
-		return common.Hash{}, fmt.Errorf("failed to send transaction: %w", err)
+		err = fmt.Errorf("failed to send transaction: %w", err)
+		if isAlreadyKnownError(err) {
+			// the transaction is in the mempool from an earlier send
+			currentObserver().OnSubmitted(tx.Hash())
+		} else {
+			currentObserver().OnFailed(tx.Hash(), err)
+		}
+		return common.Hash{}, err
 	}
 	logger.Info("Transaction sent successfully: %s", tx.Hash())
+	currentObserver().OnSubmitted(tx.Hash())
 	return tx.Hash(), nil
 }
 
+// isNonceTooLowError reports whether err is the node rejecting a nonce that another transaction already took
+func isNonceTooLowError(err error) bool {
+	return strings.Contains(strings.ToLower(err.Error()), "nonce too low")
+}
+
+// isAlreadyKnownError reports whether err is the node answering that the transaction is already in its mempool
+func isAlreadyKnownError(err error) bool {
+	return strings.Contains(strings.ToLower(err.Error()), "already known")
+}
+
+// SendTransactionWithRetry creates, signs and sends the transaction described by details from ac.
+// When the node answers "nonce too low", it refetches the pending nonce, re-signs and resends, up to retries times.
+// An "already known" answer means the transaction is in the mempool already and is treated as accepted.
+// It returns the transaction that was finally accepted.
+// SendTransaction does not retry, so tests relying on wrong-nonce failures are unaffected.
+func SendTransactionWithRetry(ctx context.Context, details TransactionDetails, ac *accounts.Account, retries int) (*types.Transaction, error) {
+	for attempt := 0; ; attempt++ {
+		tx, _, err := CreateTransaction(ctx, details, ac)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create transaction: %w", err)
+		}
+		_, err = SendTransaction(ctx, tx, ac.GetRollup())
+		if err == nil {
+			return tx, nil
+		}
+		if isAlreadyKnownError(err) {
+			logger.Info("Transaction %s is already known on %s", tx.Hash(), ac.GetRollup().Name())
+			return tx, nil
+		}
+		if attempt >= retries || !isNonceTooLowError(err) {
+			return nil, err
+		}
+		logger.Warn("Nonce %d of %s on %s was taken, retrying with a fresh nonce (retry %d/%d)", tx.Nonce(), ac.GetAddress(), ac.GetRollup().Name(), attempt+1, retries)
+	}
+}
+
+// SendAndWait creates, signs and sends the transaction described by details from ac and waits for its receipt.
+// It returns an error if the transaction reverted; the transaction and receipt are still returned in that case.
+func SendAndWait(ctx context.Context, details TransactionDetails, ac *accounts.Account) (*types.Transaction, *types.Receipt, error) {
+	tx, _, err := CreateTransaction(ctx, details, ac)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create transaction: %w", err)
+	}
+	hash, err := SendTransaction(ctx, tx, ac.GetRollup())
+	if err != nil {
+		return tx, nil, err
+	}
+	_, receipt, err := GetTransactionDetails(ctx, hash, ac.GetRollup())
+	if err != nil {
+		return tx, nil, err
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return tx, receipt, fmt.Errorf("transaction %s reverted", hash.Hex())
+	}
+	return tx, receipt, nil
+}
+
 // generateRandomSessionID returns a random big.Int in the range [0, 2^63-1]
 func GenerateRandomSessionID() *big.Int {
 	max := new(big.Int).Lsh(big.NewInt(1), 63)
@@ -128,15 +253,70 @@ func GenerateRandomSessionID() *big.Int {
 	return n
 }
 
+// SessionIDFromSeed deterministically derives a session ID in the range [0, 2^63-1] from seed.
+// The same seed always yields the same ID, which makes failures reproducible and allows crafting reused sessions.
+func SessionIDFromSeed(seed string) *big.Int {
+	hash := crypto.Keccak256([]byte(seed))
+	return new(big.Int).Rsh(new(big.Int).SetBytes(hash[:8]), 1)
+}
+
+const (
+	defaultPollInterval    = 600 * time.Millisecond
+	defaultNotFoundRetries = 10
+)
+
+// WaitOpts controls how GetTransactionDetailsWithOpts polls for a transaction
+type WaitOpts struct {
+	// NotFoundInterval is the wait between polls while the tx has not reached the RPC yet, 600ms when zero
+	NotFoundInterval time.Duration
+	// PendingInterval is the wait between polls while the tx is pending, 600ms when zero
+	PendingInterval time.Duration
+	// NotFoundRetries is how many times a tx that has not reached the RPC yet is polled before giving up with
+	// ErrReceiptNotFound, 10 when zero. A negative value keeps polling until ctx is done.
+	NotFoundRetries int
+	// Confirmations is the number of blocks that must follow the tx's block before it is returned.
+	// The head is polled every PendingInterval until then. Zero returns as soon as the receipt exists.
+	Confirmations int
+}
+
+func (o WaitOpts) withDefaults() WaitOpts {
+	if o.NotFoundInterval == 0 {
+		o.NotFoundInterval = defaultPollInterval
+	}
+	if o.PendingInterval == 0 {
+		o.PendingInterval = defaultPollInterval
+	}
+	if o.NotFoundRetries == 0 {
+		o.NotFoundRetries = defaultNotFoundRetries
+	}
+	return o
+}
+
 // GetTransactionDetails retrieves transaction details from the blockchain using the transaction hash and RPC URL
 // It will wait and retry every 600 milliseconds if the transaction is pending until it's confirmed or fails
 func GetTransactionDetails(ctx context.Context, txHash common.Hash, rollup *rollup.Rollup) (*types.Transaction, *types.Receipt, error) {
-	// Create Ethereum client
-	client, err := ethclient.DialContext(ctx, rollup.RPCURL())
+	return GetTransactionDetailsWithOpts(ctx, txHash, rollup, WaitOpts{})
+}
+
+// GetTransactionDetailsWithOpts is GetTransactionDetails with configurable polling intervals
+func GetTransactionDetailsWithOpts(ctx context.Context, txHash common.Hash, rollup *rollup.Rollup, opts WaitOpts) (*types.Transaction, *types.Receipt, error) {
+	tx, receipt, err := pollTransactionDetails(ctx, txHash, rollup, opts.withDefaults())
+	switch {
+	case err != nil:
+		currentObserver().OnFailed(txHash, err)
+	case receipt.Status != types.ReceiptStatusSuccessful:
+		currentObserver().OnFailed(txHash, fmt.Errorf("transaction %s reverted", txHash.Hex()))
+	default:
+		currentObserver().OnConfirmed(txHash)
+	}
+	return tx, receipt, err
+}
+
+func pollTransactionDetails(ctx context.Context, txHash common.Hash, rollup *rollup.Rollup, opts WaitOpts) (*types.Transaction, *types.Receipt, error) {
+	client, err := rollup.Client(ctx)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to connect to RPC URL %s: %w", rollup.RPCURL(), err)
+		return nil, nil, err
 	}
-	defer client.Close()
 
 	logger.Info("Fetching transaction details on %s for hash: %s", rollup.Name(), txHash.Hex())
 
@@ -144,26 +324,24 @@ func GetTransactionDetails(ctx context.Context, txHash common.Hash, rollup *roll
 	startTime := time.Now()
 
 	// Retry counter for "not found" errors
-	maxRetries := 10
 	retryCount := 0
-	retryInterval := 600 * time.Millisecond
 
-	// Poll for transaction status every 500 milliseconds until confirmed or failed
+	// Poll for transaction status until confirmed or failed
 	for {
 		// Get transaction by hash
 		tx, isPending, err := client.TransactionByHash(ctx, txHash)
 		if err != nil {
-			// if transaction did not reach the RPC yet, we retry every 500 milliseconds until it does, max 10 retries
+			// if transaction did not reach the RPC yet, we retry every NotFoundInterval until it does or NotFoundRetries is used up
 			if errors.Is(err, ethereum.NotFound) {
 				retryCount++
-				if retryCount > maxRetries {
-					return nil, nil, fmt.Errorf("transaction receipt not found after %d retries for hash %s", maxRetries, txHash.Hex())
+				if opts.NotFoundRetries > 0 && retryCount > opts.NotFoundRetries {
+					return nil, nil, fmt.Errorf("%w after %d retries for hash %s", ErrReceiptNotFound, opts.NotFoundRetries, txHash.Hex())
 				}
-				logger.Debug("Transaction %s did not reach the RPC yet, waiting %s before retry... (retry %d/%d)", txHash.Hex(), retryInterval, retryCount, maxRetries)
+				logger.Debug("Transaction %s did not reach the RPC yet, waiting %s before retry... (retry %d)", txHash.Hex(), opts.NotFoundInterval, retryCount)
 				select {
 				case <-ctx.Done():
-					return nil, nil, fmt.Errorf("context cancelled while waiting for transaction %s", txHash.Hex())
-				case <-time.After(retryInterval):
+					return nil, nil, fmt.Errorf("%w %s: %w: %w", ErrContextCancelled, txHash.Hex(), ErrReceiptNotFound, ctx.Err())
+				case <-time.After(opts.NotFoundInterval):
 					continue // Retry
 				}
 			}
@@ -171,13 +349,12 @@ func GetTransactionDetails(ctx context.Context, txHash common.Hash, rollup *roll
 		}
 
 		if isPending {
-			logger.Debug("Transaction %s is still pending, waiting %s before retry...", txHash.Hex(), retryInterval)
+			logger.Debug("Transaction %s is still pending, waiting %s before retry...", txHash.Hex(), opts.PendingInterval)
 
-			// Wait 500 ms before retrying
 			select {
 			case <-ctx.Done():
-				return nil, nil, fmt.Errorf("context cancelled while waiting for transaction %s", txHash.Hex())
-			case <-time.After(retryInterval):
+				return nil, nil, fmt.Errorf("%w %s: %w", ErrContextCancelled, txHash.Hex(), ctx.Err())
+			case <-time.After(opts.PendingInterval):
 				continue // Retry
 			}
 		}
@@ -188,6 +365,19 @@ func GetTransactionDetails(ctx context.Context, txHash common.Hash, rollup *roll
 			return nil, nil, fmt.Errorf("failed to get transaction receipt for hash %s: %w", txHash.Hex(), err)
 		}
 
+		if err := waitForConfirmations(ctx, receipt, rollup, opts); err != nil {
+			return nil, nil, err
+		}
+
+		if receipt.Status == types.ReceiptStatusFailed {
+			reason, err := GetReceiptRevertReason(ctx, tx, rollup, receipt)
+			if err != nil {
+				logger.Warn("Transaction %s failed on %s, could not decode revert reason: %v", txHash.Hex(), rollup.Name(), err)
+			} else {
+				logger.Warn("Transaction %s failed on %s with revert reason: %q", txHash.Hex(), rollup.Name(), reason)
+			}
+		}
+
 		duration := time.Since(startTime)
 		logger.Info("Successfully retrieved transaction details on %s for hash: %s)", rollup.Name(), txHash.Hex())
 		logger.Info("Transaction took %s to be processed", duration)
@@ -195,46 +385,185 @@ func GetTransactionDetails(ctx context.Context, txHash common.Hash, rollup *roll
 	}
 }
 
+// waitForConfirmations polls the head of rollup until receipt's block is buried opts.Confirmations blocks deep
+func waitForConfirmations(ctx context.Context, receipt *types.Receipt, rollup *rollup.Rollup, opts WaitOpts) error {
+	if opts.Confirmations <= 0 {
+		return nil
+	}
+	target := receipt.BlockNumber.Uint64() + uint64(opts.Confirmations)
+	for {
+		head, err := rollup.BlockNumber(ctx)
+		if err != nil {
+			return err
+		}
+		if head >= target {
+			return nil
+		}
+		logger.Debug("Transaction %s needs %d confirmations, waiting for block %d (head is %d)...", receipt.TxHash.Hex(), opts.Confirmations, target, head)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w %s: %w", ErrContextCancelled, receipt.TxHash.Hex(), ctx.Err())
+		case <-time.After(opts.PendingInterval):
+		}
+	}
+}
+
+// DistributeResult is the outcome of funding one recipient in DistributeEthWithResults
+type DistributeResult struct {
+	Recipient *accounts.Account
+	// TxHash is the funding transaction, zero if it was never sent
+	TxHash common.Hash
+	Err    error
+}
+
+// defaultDistributeConcurrency bounds the funding receipts DistributeEth waits for at once
+const defaultDistributeConcurrency = 8
+
 /*
 DistributeEth distributes ETH to the given recipients. Used for distributing ETH from one account to multiple accounts.
+It funds every recipient even if some fail, and returns the joined errors of the failed ones.
 */
 func DistributeEth(ctx context.Context, sponsor *accounts.Account, recipients []*accounts.Account, amount *big.Int) error {
+	var err error
+	for _, result := range DistributeEthParallel(ctx, sponsor, recipients, amount, defaultDistributeConcurrency) {
+		if result.Err != nil {
+			err = errors.Join(err, fmt.Errorf("failed to fund %s: %w", result.Recipient.GetAddress().Hex(), result.Err))
+		}
+	}
+	return err
+}
+
+/*
+DistributeEthWithResults sends amount of ETH from sponsor to each recipient and reports the outcome per recipient.
+A failed recipient does not stop the others, and its nonce is reused for the next one.
+Receipts are awaited concurrently once all transactions are sent.
+*/
+func DistributeEthWithResults(ctx context.Context, sponsor *accounts.Account, recipients []*accounts.Account, amount *big.Int) []DistributeResult {
+	results := make([]DistributeResult, len(recipients))
+	for i, recipient := range recipients {
+		results[i].Recipient = recipient
+	}
+
 	nonce, err := sponsor.GetNonce(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to get nonce: %w", err)
+		for i := range results {
+			results[i].Err = fmt.Errorf("failed to get nonce: %w", err)
+		}
+		return results
 	}
 
-	for _, recipient := range recipients {
-
-		transactionBDetails := TransactionDetails{
+	var wg sync.WaitGroup
+	for i, recipient := range recipients {
+		transactionDetails := TransactionDetails{
 			To:        recipient.GetAddress(),
 			Value:     amount,
-			Gas:       25000,
+			Gas:       configs.Values.GasProfile.Gas(configs.GasOpEthTransfer),
 			GasTipCap: big.NewInt(1000000),
 			GasFeeCap: big.NewInt(2000000),
 			Data:      nil,
 		}
 
-		tx, _, err := CreateTransactionWithNonce(ctx, transactionBDetails, sponsor, nonce)
+		tx, _, err := CreateTransactionWithNonce(ctx, transactionDetails, sponsor, nonce)
 		if err != nil {
-			return fmt.Errorf("failed to create transaction: %w", err)
+			results[i].Err = fmt.Errorf("failed to create transaction: %w", err)
+			continue
 		}
-		_, err = SendTransaction(ctx, tx, sponsor.GetRollup().RPCURL())
-		if err != nil {
-			return fmt.Errorf("failed to send transaction: %w", err)
+		if _, err := SendTransaction(ctx, tx, sponsor.GetRollup()); err != nil {
+			results[i].Err = fmt.Errorf("failed to send transaction: %w", err)
+			continue
+		}
+		results[i].TxHash = tx.Hash()
+		// increment nonce for next transaction
+		nonce++
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i].Err = waitForSuccess(ctx, tx.Hash(), sponsor.GetRollup())
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
+
+/*
+DistributeEthParallel sends amount of ETH from sponsor to each recipient, then waits for all receipts with at most
+maxConcurrency receipt lookups in flight. Nonces come from the sponsor's NonceManager, which is resynced first.
+Transactions are signed and sent in recipient order without waiting for receipts in between; a transaction that
+fails before reaching the mempool releases its nonce to the next recipient, so a failure leaves no nonce gap.
+*/
+func DistributeEthParallel(ctx context.Context, sponsor *accounts.Account, recipients []*accounts.Account, amount *big.Int, maxConcurrency int) []DistributeResult {
+	if maxConcurrency < 1 {
+		maxConcurrency = 1
+	}
+
+	results := make([]DistributeResult, len(recipients))
+	for i, recipient := range recipients {
+		results[i].Recipient = recipient
+	}
+
+	nonces := sponsor.Nonces()
+	if err := nonces.Reset(ctx); err != nil {
+		for i := range results {
+			results[i].Err = fmt.Errorf("failed to sync nonce: %w", err)
 		}
+		return results
+	}
 
-		// check if transaction is successful
-		_, receipt, err := GetTransactionDetails(ctx, tx.Hash(), sponsor.GetRollup())
+	sem := make(chan struct{}, maxConcurrency)
+	var wg sync.WaitGroup
+	for i, recipient := range recipients {
+		nonce, err := nonces.Next(ctx)
 		if err != nil {
-			return fmt.Errorf("failed to get transaction receipt: %w", err)
+			results[i].Err = fmt.Errorf("failed to get nonce: %w", err)
+			continue
 		}
-		if receipt.Status != types.ReceiptStatusSuccessful {
-			return fmt.Errorf("transaction failed: %s", tx.Hash().Hex())
+		transactionDetails := NewTxDetails(recipient.GetAddress()).
+			Value(amount).
+			Gas(configs.Values.GasProfile.Gas(configs.GasOpEthTransfer)).
+			Fees(big.NewInt(1000000), big.NewInt(2000000)).
+			Build()
+		tx, _, err := CreateTransactionWithNonce(ctx, transactionDetails, sponsor, nonce)
+		if err != nil {
+			nonces.Release(nonce)
+			results[i].Err = fmt.Errorf("failed to create transaction: %w", err)
+			continue
 		}
-		// increment nonce for next transaction
-		nonce++
+		if _, err := SendTransaction(ctx, tx, sponsor.GetRollup()); err != nil {
+			nonces.Release(nonce)
+			results[i].Err = fmt.Errorf("failed to send transaction: %w", err)
+			continue
+		}
+		results[i].TxHash = tx.Hash()
 
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				results[i].Err = fmt.Errorf("failed to get transaction receipt: %w", ctx.Err())
+				return
+			}
+			defer func() { <-sem }()
+			results[i].Err = waitForSuccess(ctx, tx.Hash(), sponsor.GetRollup())
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
+
+// waitForSuccess waits for the receipt of txHash and returns an error if the transaction failed
+func waitForSuccess(ctx context.Context, txHash common.Hash, rollup *rollup.Rollup) error {
+	_, receipt, err := GetTransactionDetails(ctx, txHash, rollup)
+	if err != nil {
+		return fmt.Errorf("failed to get transaction receipt: %w", err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return fmt.Errorf("transaction failed: %s", txHash.Hex())
 	}
 	return nil
 }
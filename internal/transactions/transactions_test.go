@@ -0,0 +1,369 @@
+package transactions
+
+import (
+	"encoding/json"
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/compose-network/dome/internal/accounts"
+	"github.com/compose-network/dome/internal/rollup"
+	"github.com/compose-network/dome/internal/rpctest"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPrivateKeyHex = "4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"
+
+// newTestAccount returns an account on a rollup with an unreachable RPC, usable for offline signing
+func newTestAccount(t *testing.T) *accounts.Account {
+	t.Helper()
+	return newTestAccountOn(t, rollup.New("http://127.0.0.1:0", big.NewInt(77777), "test-rollup"))
+}
+
+// newTestAccountOn returns an account for the test key on the given rollup
+func newTestAccountOn(t *testing.T, r *rollup.Rollup) *accounts.Account {
+	t.Helper()
+	ac, err := accounts.NewRollupAccount(testPrivateKeyHex, r)
+	require.NoError(t, err)
+	t.Cleanup(ac.Close)
+	return ac
+}
+
+func TestCreateTransactionWithNonceLegacyAndDynamicFee(t *testing.T) {
+	ac := newTestAccount(t)
+	details := TransactionDetails{
+		To:        common.HexToAddress("0x01"),
+		Value:     big.NewInt(1),
+		Gas:       21000,
+		GasTipCap: big.NewInt(1000000000),
+		GasFeeCap: big.NewInt(20000000000),
+		GasPrice:  big.NewInt(20000000000),
+	}
+
+	dynamicTx, dynamicRaw, err := CreateTransactionWithNonce(t.Context(), details, ac, 7)
+	require.NoError(t, err)
+	require.Equal(t, uint8(types.DynamicFeeTxType), dynamicTx.Type())
+	require.Equal(t, byte(types.DynamicFeeTxType), dynamicRaw[0])
+	sender, err := types.Sender(types.NewLondonSigner(ac.GetRollup().ChainID()), dynamicTx)
+	require.NoError(t, err)
+	require.Equal(t, ac.GetAddress(), sender)
+
+	details.Legacy = true
+	legacyTx, legacyRaw, err := CreateTransactionWithNonce(t.Context(), details, ac, 7)
+	require.NoError(t, err)
+	require.Equal(t, uint8(types.LegacyTxType), legacyTx.Type())
+	require.GreaterOrEqual(t, legacyRaw[0], byte(0xc0)) // legacy txs are a bare RLP list
+	require.True(t, legacyTx.Protected())
+	require.Equal(t, details.GasPrice, legacyTx.GasPrice())
+	sender, err = types.Sender(types.NewEIP155Signer(ac.GetRollup().ChainID()), legacyTx)
+	require.NoError(t, err)
+	require.Equal(t, ac.GetAddress(), sender)
+}
+
+func TestSessionIDFromSeed(t *testing.T) {
+	max := new(big.Int).Lsh(big.NewInt(1), 63)
+
+	id := SessionIDFromSeed("replay")
+	require.Equal(t, id, SessionIDFromSeed("replay"))
+	require.NotEqual(t, id, SessionIDFromSeed("replay-2"))
+	require.Equal(t, -1, id.Cmp(max))
+	require.Equal(t, 1, id.Sign())
+}
+
+func TestSendTransactionRejectsWrongChain(t *testing.T) {
+	ac := newTestAccount(t)
+	details := TransactionDetails{
+		To:        common.HexToAddress("0x01"),
+		Value:     big.NewInt(1),
+		Gas:       21000,
+		GasTipCap: big.NewInt(1000000000),
+		GasFeeCap: big.NewInt(20000000000),
+	}
+	tx, _, err := CreateTransactionWithNonce(t.Context(), details, ac, 0)
+	require.NoError(t, err)
+
+	require.NoError(t, ValidateTxForRollup(tx, ac.GetRollup()))
+
+	other := rollup.New("http://127.0.0.1:0", big.NewInt(88888), "other-rollup")
+	_, err = SendTransaction(t.Context(), tx, other)
+	require.ErrorContains(t, err, "is signed for chain ID 77777 but is being sent to other-rollup with chain ID 88888")
+}
+
+func TestDistributeEthWithResultsReportsEveryRecipient(t *testing.T) {
+	sponsor := newTestAccount(t) // unreachable RPC, so no recipient can be funded
+	recipients := []*accounts.Account{newTestAccount(t), newTestAccount(t)}
+
+	results := DistributeEthWithResults(t.Context(), sponsor, recipients, big.NewInt(1))
+	require.Len(t, results, len(recipients))
+	for i, result := range results {
+		require.Same(t, recipients[i], result.Recipient)
+		require.Equal(t, common.Hash{}, result.TxHash)
+		require.ErrorContains(t, result.Err, "failed to get nonce")
+	}
+
+	require.ErrorContains(t, DistributeEth(t.Context(), sponsor, recipients, big.NewInt(1)), "failed to fund "+recipients[1].GetAddress().Hex())
+}
+
+func TestDistributeEthParallelReportsEveryRecipient(t *testing.T) {
+	sponsor := newTestAccount(t) // unreachable RPC, so the nonce cannot be synced
+	recipients := []*accounts.Account{newTestAccount(t), newTestAccount(t), newTestAccount(t)}
+
+	results := DistributeEthParallel(t.Context(), sponsor, recipients, big.NewInt(1), 2)
+	require.Len(t, results, len(recipients))
+	for i, result := range results {
+		require.Same(t, recipients[i], result.Recipient)
+		require.ErrorContains(t, result.Err, "failed to sync nonce")
+	}
+}
+
+func TestDistributeEthParallelReusesNonceOfFailedSend(t *testing.T) {
+	// the node rejects the first funding tx; the recipients after it must still be funded without a nonce gap
+	var mu sync.Mutex
+	var sentNonces []uint64
+	chain := rpctest.NewChain(t)
+	server := rpctest.NewServer(t, func(req rpctest.Request) interface{} {
+		mu.Lock()
+		defer mu.Unlock()
+		switch req.Method {
+		case "eth_chainId":
+			return rpctest.ChainID
+		case "eth_getTransactionCount":
+			return "0x5"
+		case "eth_sendRawTransaction":
+			var raw hexutil.Bytes
+			assert.NoError(t, json.Unmarshal(req.Params[0], &raw))
+			var tx types.Transaction
+			assert.NoError(t, tx.UnmarshalBinary(raw))
+			sentNonces = append(sentNonces, tx.Nonce())
+			if len(sentNonces) == 1 {
+				return &rpctest.Error{Code: -32000, Message: "insufficient funds for gas * price + value"}
+			}
+			chain.Mine(&tx, types.ReceiptStatusSuccessful, 1)
+			return tx.Hash()
+		}
+		if result, ok := chain.Handle(req); ok {
+			return result
+		}
+		t.Errorf("unexpected method %s", req.Method)
+		return nil
+	})
+	sponsor := newTestAccountOn(t, rollup.New(server.URL, big.NewInt(77777), "test-rollup"))
+	recipients := []*accounts.Account{newTestAccount(t), newTestAccount(t), newTestAccount(t)}
+
+	results := DistributeEthParallel(t.Context(), sponsor, recipients, big.NewInt(1), 2)
+	require.ErrorContains(t, results[0].Err, "failed to send transaction")
+	require.Equal(t, common.Hash{}, results[0].TxHash)
+	require.NoError(t, results[1].Err)
+	require.NoError(t, results[2].Err)
+	require.Equal(t, []uint64{5, 5, 6}, sentNonces)
+
+	nonce, err := sponsor.Nonces().Next(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, uint64(7), nonce)
+}
+
+func TestRawBytesMatchesCreateTransaction(t *testing.T) {
+	ac := newTestAccount(t)
+	details := TransactionDetails{
+		To:        common.HexToAddress("0x01"),
+		Value:     big.NewInt(1),
+		Gas:       21000,
+		GasTipCap: big.NewInt(1000000000),
+		GasFeeCap: big.NewInt(20000000000),
+	}
+	tx, marshaledTx, err := CreateTransactionWithNonce(t.Context(), details, ac, 0)
+	require.NoError(t, err)
+
+	raw, err := RawBytes(tx)
+	require.NoError(t, err)
+	require.Equal(t, marshaledTx, raw)
+
+	var decoded types.Transaction
+	require.NoError(t, decoded.UnmarshalBinary(raw))
+	require.Equal(t, tx.Hash(), decoded.Hash())
+}
+
+func TestSendTransactionWithRetryRefetchesNonce(t *testing.T) {
+	var nonceQueries, sends atomic.Int32
+	var sentNonces []uint64
+	server := rpctest.NewServer(t, func(req rpctest.Request) interface{} {
+		switch req.Method {
+		case "eth_chainId":
+			return rpctest.ChainID
+		case "eth_getTransactionCount":
+			// another sender takes nonce 0 between the first query and the send
+			return hexutil.Uint64(nonceQueries.Add(1) - 1)
+		case "eth_sendRawTransaction":
+			var raw hexutil.Bytes
+			assert.NoError(t, json.Unmarshal(req.Params[0], &raw))
+			var tx types.Transaction
+			assert.NoError(t, tx.UnmarshalBinary(raw))
+			sentNonces = append(sentNonces, tx.Nonce())
+			if sends.Add(1) == 1 {
+				return &rpctest.Error{Code: -32000, Message: "nonce too low: next nonce 1, tx nonce 0"}
+			}
+			return tx.Hash()
+		}
+		t.Errorf("unexpected method %s", req.Method)
+		return nil
+	})
+
+	ac := newTestAccountOn(t, rollup.New(server.URL, big.NewInt(77777), "test-rollup"))
+	details := NewTxDetails(common.HexToAddress("0x01")).Gas(21000).Fees(GWei(1), GWei(20)).Build()
+
+	tx, err := SendTransactionWithRetry(t.Context(), details, ac, 2)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), tx.Nonce())
+	require.Equal(t, []uint64{0, 1}, sentNonces)
+
+	// without retries the race surfaces to the caller
+	sends.Store(0)
+	nonceQueries.Store(0)
+	_, err = SendTransactionWithRetry(t.Context(), details, ac, 0)
+	require.ErrorContains(t, err, "nonce too low")
+}
+
+func TestSendTransactionWithRetryAcceptsAlreadyKnown(t *testing.T) {
+	// an earlier send of the same tx already reached the mempool, so it must not be re-signed and sent again
+	var sends atomic.Int32
+	server := rpctest.NewServer(t, func(req rpctest.Request) interface{} {
+		switch req.Method {
+		case "eth_chainId":
+			return rpctest.ChainID
+		case "eth_getTransactionCount":
+			return "0x0"
+		case "eth_sendRawTransaction":
+			sends.Add(1)
+			return &rpctest.Error{Code: -32000, Message: "already known"}
+		}
+		t.Errorf("unexpected method %s", req.Method)
+		return nil
+	})
+
+	ac := newTestAccountOn(t, rollup.New(server.URL, big.NewInt(77777), "test-rollup"))
+	details := NewTxDetails(common.HexToAddress("0x01")).Gas(21000).Fees(GWei(1), GWei(20)).Build()
+
+	tx, err := SendTransactionWithRetry(t.Context(), details, ac, 2)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), tx.Nonce())
+	require.Equal(t, int32(1), sends.Load())
+}
+
+func TestGetTransactionDetailsWithOptsHonorsIntervals(t *testing.T) {
+	key, err := crypto.HexToECDSA(testPrivateKeyHex)
+	require.NoError(t, err)
+	tx := rpctest.SignTx(t, key, 0)
+
+	// the tx is not found on the first lookup, pending on the second and mined on the third
+	var lookups []time.Time
+	server := rpctest.NewServer(t, func(req rpctest.Request) interface{} {
+		switch req.Method {
+		case "eth_chainId":
+			return "0x12fd1"
+		case "eth_getTransactionByHash":
+			lookups = append(lookups, time.Now())
+			switch len(lookups) {
+			case 1:
+				return nil
+			case 2:
+				return rpctest.PendingTx(t, tx)
+			}
+			return rpctest.MinedTx(t, tx, 1)
+		case "eth_getTransactionReceipt":
+			return rpctest.Receipt(tx.Hash(), types.ReceiptStatusSuccessful, 1)
+		}
+		return nil
+	})
+	r := rollup.New(server.URL, big.NewInt(77777), "test-rollup")
+	defer r.Close()
+
+	opts := WaitOpts{NotFoundInterval: 20 * time.Millisecond, PendingInterval: 300 * time.Millisecond}
+	_, receipt, err := GetTransactionDetailsWithOpts(t.Context(), tx.Hash(), r, opts)
+	require.NoError(t, err)
+	require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+	require.Len(t, lookups, 3)
+
+	notFoundWait := lookups[1].Sub(lookups[0])
+	pendingWait := lookups[2].Sub(lookups[1])
+	require.GreaterOrEqual(t, notFoundWait, opts.NotFoundInterval)
+	require.Less(t, notFoundWait, opts.PendingInterval)
+	require.GreaterOrEqual(t, pendingWait, opts.PendingInterval)
+}
+
+func TestGetTransactionDetailsWithOptsNotFoundRetries(t *testing.T) {
+	key, err := crypto.HexToECDSA(testPrivateKeyHex)
+	require.NoError(t, err)
+	tx := rpctest.SignTx(t, key, 0)
+
+	// the tx only reaches the node after more lookups than the default retry cap
+	var lookups atomic.Int32
+	server := rpctest.NewServer(t, func(req rpctest.Request) interface{} {
+		switch req.Method {
+		case "eth_chainId":
+			return "0x12fd1"
+		case "eth_getTransactionByHash":
+			if lookups.Add(1) <= 15 {
+				return nil
+			}
+			return rpctest.MinedTx(t, tx, 1)
+		case "eth_getTransactionReceipt":
+			return rpctest.Receipt(tx.Hash(), types.ReceiptStatusSuccessful, 1)
+		}
+		return nil
+	})
+	r := rollup.New(server.URL, big.NewInt(77777), "test-rollup")
+	defer r.Close()
+
+	_, _, err = GetTransactionDetailsWithOpts(t.Context(), tx.Hash(), r, WaitOpts{NotFoundInterval: time.Millisecond})
+	require.ErrorIs(t, err, ErrReceiptNotFound)
+	require.EqualValues(t, 11, lookups.Load())
+
+	lookups.Store(0)
+	_, receipt, err := GetTransactionDetailsWithOpts(t.Context(), tx.Hash(), r, WaitOpts{NotFoundInterval: time.Millisecond, NotFoundRetries: -1})
+	require.NoError(t, err)
+	require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+	require.EqualValues(t, 16, lookups.Load())
+}
+
+func TestGetTransactionDetailsWithOptsWaitsForConfirmations(t *testing.T) {
+	key, err := crypto.HexToECDSA(testPrivateKeyHex)
+	require.NoError(t, err)
+	tx := rpctest.SignTx(t, key, 0)
+
+	// the tx is mined in block 5 and the head advances by one block on every query
+	var head atomic.Uint64
+	head.Store(4)
+	server := rpctest.NewServer(t, func(req rpctest.Request) interface{} {
+		switch req.Method {
+		case "eth_chainId":
+			return "0x12fd1"
+		case "eth_blockNumber":
+			return hexutil.Uint64(head.Add(1))
+		case "eth_getTransactionByHash":
+			return rpctest.MinedTx(t, tx, 5)
+		case "eth_getTransactionReceipt":
+			return rpctest.Receipt(tx.Hash(), types.ReceiptStatusSuccessful, 5)
+		}
+		return nil
+	})
+	r := rollup.New(server.URL, big.NewInt(77777), "test-rollup")
+	defer r.Close()
+
+	_, receipt, err := GetTransactionDetailsWithOpts(t.Context(), tx.Hash(), r, WaitOpts{PendingInterval: time.Millisecond, Confirmations: 3})
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), receipt.BlockNumber.Uint64())
+	require.Equal(t, uint64(8), head.Load())
+
+	// without confirmations the head is never queried
+	head.Store(0)
+	_, _, err = GetTransactionDetailsWithOpts(t.Context(), tx.Hash(), r, WaitOpts{})
+	require.NoError(t, err)
+	require.Zero(t, head.Load())
+}
@@ -0,0 +1,14 @@
+package transactions
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitConversions(t *testing.T) {
+	require.Equal(t, big.NewInt(20000000000), GWei(20))
+	require.Equal(t, big.NewInt(1000000000000000000), Ether(1))
+	require.Equal(t, new(big.Int).Mul(big.NewInt(500), big.NewInt(1000000000000000000)), Ether(500))
+}
@@ -0,0 +1,194 @@
+package transactions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/compose-network/dome/internal/logger"
+	"github.com/compose-network/dome/internal/rollup"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const eventPollInterval = 600 * time.Millisecond
+
+// ErrEventNotFound is returned by WaitForEvent when the event does not appear before the timeout
+var ErrEventNotFound = errors.New("event not found")
+
+// WaitForReceipts waits concurrently for the receipts of all txs on rollup and returns them in the order of txs.
+// Transactions that have not reached the node yet are polled until ctx is done, so ctx's deadline sets the budget.
+// The first failure, including ctx being done, cancels the remaining waits and is returned.
+// Reverted transactions are not an error; check the receipt status.
+func WaitForReceipts(ctx context.Context, txs []*types.Transaction, rollup *rollup.Rollup) ([]*types.Receipt, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+		receipts = make([]*types.Receipt, len(txs))
+	)
+	for i, tx := range txs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, receipt, err := GetTransactionDetailsWithOpts(ctx, tx.Hash(), rollup, WaitOpts{NotFoundRetries: -1})
+			if err != nil {
+				once.Do(func() {
+					firstErr = fmt.Errorf("failed waiting for tx %s on %s: %w", tx.Hash().Hex(), rollup.Name(), err)
+					cancel()
+				})
+				return
+			}
+			receipts[i] = receipt
+		}()
+	}
+	wg.Wait()
+
+	return receipts, firstErr
+}
+
+// WaitLeg identifies one leg of a cross tx to wait for
+type WaitLeg struct {
+	TxHash common.Hash
+	Rollup *rollup.Rollup
+}
+
+// SendCrossTxAndWait submits the encoded cross tx request to rpcURL and waits concurrently for all legs to be mined.
+// The mined transactions, as the rollups report them, and their receipts are returned in the order of legs.
+// It returns an error if the request is rejected or any leg reverts or is not mined, naming the revert reason of
+// reverted legs; the transactions and receipts of the legs that were mined are still returned in that case.
+func SendCrossTxAndWait(ctx context.Context, rpcURL string, encoded []byte, legs []WaitLeg) ([]*types.Transaction, []*types.Receipt, error) {
+	if _, err := SendCrossTxRequestMsg(ctx, rpcURL, encoded); err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		errs     []error
+		txs      = make([]*types.Transaction, len(legs))
+		receipts = make([]*types.Receipt, len(legs))
+	)
+	for i, leg := range legs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tx, receipt, err := GetTransactionDetails(ctx, leg.TxHash, leg.Rollup)
+			switch {
+			case err != nil:
+				err = fmt.Errorf("failed waiting for leg %d tx %s on %s: %w", i, leg.TxHash.Hex(), leg.Rollup.Name(), err)
+			case receipt.Status != types.ReceiptStatusSuccessful:
+				reason, reasonErr := GetReceiptRevertReason(ctx, tx, leg.Rollup, receipt)
+				if reasonErr != nil {
+					reason = fmt.Sprintf("unavailable: %v", reasonErr)
+				}
+				err = fmt.Errorf("leg %d tx %s reverted on %s in block %s (gas used %d of %d, reason: %s)",
+					i, leg.TxHash.Hex(), leg.Rollup.Name(), receipt.BlockNumber, receipt.GasUsed, tx.Gas(), reason)
+			}
+			txs[i], receipts[i] = tx, receipt
+			if err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	return txs, receipts, errors.Join(errs...)
+}
+
+// WaitForReceiptSub waits for the receipt of txHash by checking for it on every new head of rollup,
+// instead of polling like GetTransactionDetails. It falls back to GetTransactionDetails when the rollup
+// has no WebSocket URL or the subscription ends early.
+func WaitForReceiptSub(ctx context.Context, txHash common.Hash, rollup *rollup.Rollup) (*types.Receipt, error) {
+	if rollup.WSURL() == "" {
+		_, receipt, err := GetTransactionDetails(ctx, txHash, rollup)
+		return receipt, err
+	}
+
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	heads, err := rollup.SubscribeNewHeads(subCtx)
+	if err != nil {
+		return nil, err
+	}
+	client, err := rollup.Client(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// the tx may have been mined before the subscription started
+	for {
+		receipt, err := client.TransactionReceipt(ctx, txHash)
+		if err == nil {
+			return receipt, nil
+		}
+		if !errors.Is(err, ethereum.NotFound) {
+			return nil, fmt.Errorf("failed to get transaction receipt for hash %s: %w", txHash.Hex(), err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%w %s: %w", ErrContextCancelled, txHash.Hex(), ctx.Err())
+		case _, ok := <-heads:
+			if !ok {
+				logger.Warn("New heads subscription on %s ended, polling for %s instead", rollup.Name(), txHash.Hex())
+				_, receipt, err := GetTransactionDetails(ctx, txHash, rollup)
+				return receipt, err
+			}
+		}
+	}
+}
+
+// WaitForEvent polls rollup for the first log with topic eventID emitted by contract at or after fromBlock.
+// It gives up after timeout, or when ctx is done, returning an error naming the scanned block range.
+func WaitForEvent(ctx context.Context, rollup *rollup.Rollup, contract common.Address, eventID common.Hash, fromBlock uint64, timeout time.Duration) (*types.Log, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	client, err := rollup.Client(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	next := fromBlock
+	for {
+		latest, err := rollup.BlockNumber(ctx)
+		if err != nil && ctx.Err() == nil {
+			return nil, err
+		}
+		if err == nil && latest >= next {
+			logs, err := client.FilterLogs(ctx, ethereum.FilterQuery{
+				FromBlock: new(big.Int).SetUint64(next),
+				ToBlock:   new(big.Int).SetUint64(latest),
+				Addresses: []common.Address{contract},
+				Topics:    [][]common.Hash{{eventID}},
+			})
+			if err != nil && ctx.Err() == nil {
+				return nil, fmt.Errorf("failed to filter logs on %s: %w", rollup.Name(), err)
+			}
+			if err == nil {
+				if len(logs) > 0 {
+					return &logs[0], nil
+				}
+				next = latest + 1
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			if next == fromBlock {
+				return nil, fmt.Errorf("%w: event %s of %s on %s, no blocks scanned from %d: %w", ErrEventNotFound, eventID.Hex(), contract.Hex(), rollup.Name(), fromBlock, ctx.Err())
+			}
+			return nil, fmt.Errorf("%w: event %s of %s on %s, scanned blocks %d-%d: %w", ErrEventNotFound, eventID.Hex(), contract.Hex(), rollup.Name(), fromBlock, next-1, ctx.Err())
+		case <-time.After(eventPollInterval):
+		}
+	}
+}
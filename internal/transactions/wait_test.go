@@ -0,0 +1,221 @@
+package transactions
+
+import (
+	"context"
+	"math/big"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/compose-network/dome/internal/rollup"
+	"github.com/compose-network/dome/internal/rpctest"
+	"github.com/compose-network/dome/internal/transactions/mock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaitForReceiptsReturnsWhenContextIsDone(t *testing.T) {
+	// the node never finds the transactions, so only the context can end the wait
+	server := rpctest.NewServer(t, func(req rpctest.Request) interface{} {
+		if req.Method == "eth_chainId" {
+			return "0x12fd1"
+		}
+		return nil
+	})
+	r := rollup.New(server.URL, big.NewInt(77777), "test-rollup")
+	defer r.Close()
+
+	txs := []*types.Transaction{
+		types.NewTx(&types.LegacyTx{Nonce: 0, To: &common.Address{}}),
+		types.NewTx(&types.LegacyTx{Nonce: 1, To: &common.Address{}}),
+	}
+
+	ctx, cancel := context.WithTimeout(t.Context(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	receipts, err := WaitForReceipts(ctx, txs, r)
+	require.ErrorIs(t, err, ErrContextCancelled)
+	require.ErrorIs(t, err, ErrReceiptNotFound)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Len(t, receipts, len(txs))
+	require.Less(t, time.Since(start), 2*time.Second)
+}
+
+// testEthService is an eth namespace serving chain 77777 whose single receipt becomes available once mined is set
+type testEthService struct {
+	heads   chan *types.Header
+	mined   atomic.Bool
+	receipt *types.Receipt
+}
+
+func (s *testEthService) ChainId() *hexutil.Big {
+	return (*hexutil.Big)(big.NewInt(77777))
+}
+
+func (s *testEthService) GetTransactionReceipt(common.Hash) (*types.Receipt, error) {
+	if !s.mined.Load() {
+		return nil, nil
+	}
+	return s.receipt, nil
+}
+
+func (s *testEthService) NewHeads(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, ok := rpc.NotifierFromContext(ctx)
+	if !ok {
+		return nil, rpc.ErrNotificationsUnsupported
+	}
+	sub := notifier.CreateSubscription()
+	go func() {
+		for {
+			select {
+			case header := <-s.heads:
+				_ = notifier.Notify(sub.ID, header)
+			case <-sub.Err():
+				return
+			}
+		}
+	}()
+	return sub, nil
+}
+
+func TestWaitForReceiptSubWatchesNewHeads(t *testing.T) {
+	txHash := common.HexToHash("0x01")
+	service := &testEthService{
+		heads: make(chan *types.Header, 1),
+		receipt: &types.Receipt{
+			Type: types.DynamicFeeTxType, Status: types.ReceiptStatusSuccessful, TxHash: txHash,
+			BlockNumber: big.NewInt(1), Logs: []*types.Log{},
+		},
+	}
+	server := rpc.NewServer()
+	t.Cleanup(server.Stop)
+	require.NoError(t, server.RegisterName("eth", service))
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+	wsServer := httptest.NewServer(server.WebsocketHandler([]string{"*"}))
+	t.Cleanup(wsServer.Close)
+
+	r := rollup.New(httpServer.URL, big.NewInt(77777), "test-rollup").WithWSURL("ws" + strings.TrimPrefix(wsServer.URL, "http"))
+	t.Cleanup(r.Close)
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		service.mined.Store(true)
+		service.heads <- &types.Header{Number: big.NewInt(1), Difficulty: big.NewInt(0)}
+	}()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+	receipt, err := WaitForReceiptSub(ctx, txHash, r)
+	require.NoError(t, err)
+	require.Equal(t, txHash, receipt.TxHash)
+	require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+}
+
+func TestSubscribeNewHeadsRequiresWSURL(t *testing.T) {
+	r := rollup.New("http://127.0.0.1:0", big.NewInt(77777), "test-rollup")
+	_, err := r.SubscribeNewHeads(t.Context())
+	require.ErrorIs(t, err, rollup.ErrNoWSURL)
+}
+
+func TestWaitForEventPollsUntilLogAppears(t *testing.T) {
+	contract := common.HexToAddress("0x1234")
+	eventID := common.HexToHash("0xabcd")
+	// a block is produced on every poll and the event is emitted in block 6
+	var head atomic.Uint64
+	head.Store(4)
+	server := rpctest.NewServer(t, func(req rpctest.Request) interface{} {
+		switch req.Method {
+		case "eth_chainId":
+			return "0x12fd1"
+		case "eth_blockNumber":
+			return hexutil.Uint64(head.Add(1))
+		case "eth_getLogs":
+			if head.Load() < 6 {
+				return []types.Log{}
+			}
+			return []types.Log{{Address: contract, Topics: []common.Hash{eventID}, BlockNumber: 6}}
+		}
+		return nil
+	})
+	r := rollup.New(server.URL, big.NewInt(77777), "test-rollup")
+	defer r.Close()
+
+	log, err := WaitForEvent(t.Context(), r, contract, eventID, 3, 5*time.Second)
+	require.NoError(t, err)
+	require.Equal(t, contract, log.Address)
+	require.Equal(t, uint64(6), log.BlockNumber)
+}
+
+func TestWaitForEventTimesOutWithScannedRange(t *testing.T) {
+	server := rpctest.NewServer(t, func(req rpctest.Request) interface{} {
+		switch req.Method {
+		case "eth_chainId":
+			return "0x12fd1"
+		case "eth_blockNumber":
+			return "0x7"
+		case "eth_getLogs":
+			return []types.Log{}
+		}
+		return nil
+	})
+	r := rollup.New(server.URL, big.NewInt(77777), "test-rollup")
+	defer r.Close()
+
+	_, err := WaitForEvent(t.Context(), r, common.HexToAddress("0x1234"), common.HexToHash("0xabcd"), 3, 200*time.Millisecond)
+	require.ErrorIs(t, err, ErrEventNotFound)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Contains(t, err.Error(), "scanned blocks 3-7")
+}
+
+func TestSendCrossTxAndWaitReportsRevertedLeg(t *testing.T) {
+	coordinator := mock.NewCoordinator()
+	defer coordinator.Close()
+
+	key, err := crypto.HexToECDSA(testPrivateKeyHex)
+	require.NoError(t, err)
+	chain := rpctest.NewChain(t)
+	succeeded := rpctest.SignTx(t, key, 0)
+	chain.Mine(succeeded, types.ReceiptStatusSuccessful, 1)
+	reverted := rpctest.SignTx(t, key, 1)
+	chain.Mine(reverted, types.ReceiptStatusFailed, 1)
+
+	server := rpctest.NewServer(t, func(req rpctest.Request) interface{} {
+		if req.Method == "eth_chainId" {
+			return rpctest.ChainID
+		}
+		if req.Method == "eth_call" {
+			return &rpctest.Error{Code: 3, Message: "execution reverted", Data: insufficientBalanceRevert}
+		}
+		result, _ := chain.Handle(req)
+		return result
+	})
+	r := rollup.New(server.URL, big.NewInt(77777), "test-rollup")
+	defer r.Close()
+
+	encoded, err := CreateCrossTxRequestMsgRaw([]RawLeg{{ChainID: 77777, SignedTx: []byte{0x0a}}, {ChainID: 88888, SignedTx: []byte{0x0b}}})
+	require.NoError(t, err)
+
+	txs, receipts, err := SendCrossTxAndWait(t.Context(), coordinator.URL(), encoded, []WaitLeg{{TxHash: succeeded.Hash(), Rollup: r}})
+	require.NoError(t, err)
+	require.Len(t, receipts, 1)
+	require.Equal(t, types.ReceiptStatusSuccessful, receipts[0].Status)
+	require.Equal(t, succeeded.Hash(), txs[0].Hash())
+	require.Equal(t, *succeeded.To(), *txs[0].To())
+
+	legs := []WaitLeg{{TxHash: succeeded.Hash(), Rollup: r}, {TxHash: reverted.Hash(), Rollup: r}}
+	txs, receipts, err = SendCrossTxAndWait(t.Context(), coordinator.URL(), encoded, legs)
+	require.ErrorContains(t, err, "leg 1 tx "+reverted.Hash().Hex()+" reverted")
+	require.ErrorContains(t, err, "reason: insufficient balance")
+	require.NotContains(t, err.Error(), succeeded.Hash().Hex())
+	require.Len(t, receipts, 2)
+	require.Equal(t, types.ReceiptStatusFailed, receipts[1].Status)
+	require.Equal(t, reverted.Hash(), txs[1].Hash())
+	require.Len(t, coordinator.Requests(), 2)
+}
@@ -2,14 +2,17 @@ package test
 
 import (
 	"context"
+	"fmt"
 	"math/big"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/compose-network/dome/configs"
+	"github.com/compose-network/dome/internal/helpers"
 	"github.com/compose-network/dome/internal/transactions"
 )
 
@@ -25,6 +28,11 @@ func TestMain(m *testing.M) {
 	// Run all tests
 	code := m.Run()
 
+	// Teardown (afterAll equivalent)
+	for _, r := range TestRollups {
+		r.Close()
+	}
+
 	// Exit with the same code as the tests
 	os.Exit(code)
 }
@@ -48,8 +56,8 @@ func Dummy(t *testing.T) {
 		To:        TestAccountA.GetAddress(),
 		Value:     big.NewInt(10000),
 		Gas:       900000,
-		GasTipCap: big.NewInt(1000000000),
-		GasFeeCap: big.NewInt(20000000000),
+		GasTipCap: transactions.GWei(1),
+		GasFeeCap: transactions.GWei(20),
 		Data:      nil,
 	}
 
@@ -75,8 +83,8 @@ func Dummy(t *testing.T) {
 		To:        bridgeAddr,
 		Value:     big.NewInt(0),
 		Gas:       900000,
-		GasTipCap: big.NewInt(1000000000),
-		GasFeeCap: big.NewInt(20000000000),
+		GasTipCap: transactions.GWei(1),
+		GasFeeCap: transactions.GWei(20),
 		Data:      calldataB,
 	}
 
@@ -92,7 +100,7 @@ func Dummy(t *testing.T) {
 	require.NotNil(t, crossTxRequestMsg)
 
 	// send cross tx request msg
-	err = transactions.SendCrossTxRequestMsg(ctx, TestRollupA.RPCURL(), crossTxRequestMsg)
+	_, err = transactions.SendCrossTxRequestMsg(ctx, TestRollupA.RPCURL(), crossTxRequestMsg)
 	require.NoError(t, err)
 
 	// both tx should not be sent to the chain
@@ -104,3 +112,31 @@ func Dummy(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to get transaction by hash")
 }
+
+/*
+TestBridgeRejectsReusedSessionID sends two bridge txs from A to B that share a seeded session ID
+  - check that the first cross tx is executed on both chains
+  - check that the second cross tx reusing the session is not executed on either chain
+*/
+func TestBridgeRejectsReusedSessionID(t *testing.T) {
+	ctx := t.Context()
+	amount := big.NewInt(1000)
+
+	// the seed is unique per run so the first use of the session is always fresh
+	sessionID := transactions.SessionIDFromSeed(fmt.Sprintf("%s-%d", t.Name(), time.Now().UnixNano()))
+
+	txA, txB, _, _, err := helpers.SendBridgeTxWithSessionID(t, TestAccountA, TestAccountB, amount, sessionID, TokenABI, BridgeABI)
+	require.NoError(t, err)
+
+	helpers.RequireTxSuccess(t, ctx, txA, TestRollupA)
+	helpers.RequireTxSuccess(t, ctx, txB, TestRollupB)
+
+	// replay the same session
+	txA, txB, _, _, err = helpers.SendBridgeTxWithSessionID(t, TestAccountA, TestAccountB, amount, sessionID, TokenABI, BridgeABI)
+	require.NoError(t, err)
+
+	var batch transactions.BatchResult
+	batch.Add(txA, TestRollupA, transactions.ExpectNotIncluded)
+	batch.Add(txB, TestRollupB, transactions.ExpectNotIncluded)
+	verifyBatch(t, &batch, notIncludedTimeout)
+}
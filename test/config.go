@@ -2,9 +2,7 @@ package test
 
 import (
 	"context"
-	"math/big"
 	"os"
-	"strings"
 
 	"github.com/compose-network/dome/configs"
 	"github.com/compose-network/dome/internal/accounts"
@@ -16,6 +14,10 @@ import (
 
 // Global test variables
 var (
+	// TestRollups and TestAccounts hold one entry per configured chain.
+	// TestRollupA/B and TestAccountA/B point at the first two chains in sorted name order.
+	TestRollups  map[configs.ChainName]*rollup.Rollup
+	TestAccounts map[configs.ChainName]*accounts.Account
 	TestRollupA  *rollup.Rollup
 	TestRollupB  *rollup.Rollup
 	TestAccountA *accounts.Account
@@ -32,47 +34,36 @@ func setup(ctx context.Context) {
 	}
 	logger.SetLogLevelFromString(logLevel)
 
-	var (
-		err             error
-		chainConfigs    = configs.Values.L2.ChainConfigs
-		contractConfigs = configs.Values.L2.Contracts
-	)
+	var err error
 
-	TestRollupA = rollup.New(chainConfigs[configs.ChainNameRollupA].RPCURL, big.NewInt(chainConfigs[configs.ChainNameRollupA].ID), string(configs.ChainNameRollupA))
-	TestRollupB = rollup.New(chainConfigs[configs.ChainNameRollupB].RPCURL, big.NewInt(chainConfigs[configs.ChainNameRollupB].ID), string(configs.ChainNameRollupB))
-
-	TestAccountA, err = accounts.NewRollupAccount(chainConfigs[configs.ChainNameRollupA].PK, TestRollupA)
+	TestAccounts, err = accounts.DefaultAccounts()
 	if err != nil {
-		panic("Failed to create account A: " + err.Error())
+		panic("Failed to create default accounts: " + err.Error())
 	}
 
-	TestAccountB, err = accounts.NewRollupAccount(chainConfigs[configs.ChainNameRollupB].PK, TestRollupB)
-	if err != nil {
-		panic("Failed to create account B: " + err.Error())
-	}
-
-	BridgeABI, err = abi.JSON(strings.NewReader(contractConfigs[configs.ContractNameBridge].ABI))
-	if err != nil {
-		panic("Failed to parse ABI: " + err.Error())
+	chainNames := configs.Values.L2.ChainNames()
+	TestRollups = make(map[configs.ChainName]*rollup.Rollup, len(chainNames))
+	for _, name := range chainNames {
+		TestRollups[name] = TestAccounts[name].GetRollup()
+		if err = TestRollups[name].HealthCheck(ctx); err != nil {
+			panic("Health check failed: " + err.Error())
+		}
 	}
 
-	TokenABI, err = abi.JSON(strings.NewReader(contractConfigs[configs.ContractNameToken].ABI))
-	if err != nil {
-		panic("Failed to parse ABI: " + err.Error())
-	}
+	TestRollupA, TestAccountA = TestRollups[chainNames[0]], TestAccounts[chainNames[0]]
+	TestRollupB, TestAccountB = TestRollups[chainNames[1]], TestAccounts[chainNames[1]]
 
-	pingPongABI, err = abi.JSON(strings.NewReader(contractConfigs[configs.ContractNamePingPong].ABI))
+	contractABIs, err := configs.ABIs()
 	if err != nil {
-		panic("Failed to parse ABI: " + err.Error())
+		panic("Failed to load ABIs: " + err.Error())
 	}
+	BridgeABI, TokenABI, pingPongABI = contractABIs.Bridge, contractABIs.Token, contractABIs.PingPong
 
 	// approve tokens for the main accounts
-	_, _, err = helpers.DefaultApproveTokens(context.Background(), TestAccountA, configs.Values.L2.Contracts[configs.ContractNameBridge].Address, TokenABI)
-	if err != nil {
-		panic("Failed to approve tokens for TestAccountA: " + err.Error())
-	}
-	_, _, err = helpers.DefaultApproveTokens(context.Background(), TestAccountB, configs.Values.L2.Contracts[configs.ContractNameBridge].Address, TokenABI)
-	if err != nil {
-		panic("Failed to approve tokens for TestAccountB: " + err.Error())
+	for _, name := range chainNames {
+		_, _, err = helpers.DefaultApproveTokens(context.Background(), TestAccounts[name], configs.Values.L2.Contracts[configs.ContractNameBridge].Address, TokenABI)
+		if err != nil {
+			panic("Failed to approve tokens for account on " + string(name) + ": " + err.Error())
+		}
 	}
 }
@@ -0,0 +1,136 @@
+package test
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/compose-network/dome/configs"
+	"github.com/compose-network/dome/internal/accounts"
+	"github.com/compose-network/dome/internal/helpers"
+	"github.com/compose-network/dome/internal/rollup"
+	"github.com/compose-network/dome/internal/transactions"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+/*
+TestTransferToken transfers tokens from TestAccountA to a fresh account on rollup A
+  - check that the sender's balance decreases and the receiver's balance increases by the transferred amount
+*/
+func TestTransferToken(t *testing.T) {
+	ctx := t.Context()
+	tokenAddress := configs.Values.L2.Contracts[configs.ContractNameToken].Address
+	amount := big.NewInt(100000000000000000) // 0.1 tokens
+
+	receiver := newRandomAccount(t, TestRollupA)
+
+	_, _, err := transactions.Mint(ctx, TestAccountA, amount, TokenABI)
+	require.NoError(t, err)
+
+	initialBalanceSender, err := TestAccountA.GetTokensBalance(ctx, tokenAddress, TokenABI)
+	require.NoError(t, err)
+
+	_, _, err = transactions.TransferToken(ctx, TestAccountA, tokenAddress, receiver.GetAddress(), amount, TokenABI)
+	require.NoError(t, err)
+
+	balanceSender, err := TestAccountA.GetTokensBalance(ctx, tokenAddress, TokenABI)
+	require.NoError(t, err)
+	balanceReceiver, err := receiver.GetTokensBalance(ctx, tokenAddress, TokenABI)
+	require.NoError(t, err)
+	require.Equal(t, new(big.Int).Sub(initialBalanceSender, amount), balanceSender)
+	require.Equal(t, amount, balanceReceiver)
+}
+
+/*
+TestTransferFromToken lets a fresh spender account pull tokens approved by TestAccountA on rollup A
+  - check that the owner's balance decreases and the spender's balance increases by the transferred amount
+*/
+func TestTransferFromToken(t *testing.T) {
+	ctx := t.Context()
+	tokenAddress := configs.Values.L2.Contracts[configs.ContractNameToken].Address
+	amount := big.NewInt(100000000000000000) // 0.1 tokens
+
+	spender := newRandomAccount(t, TestRollupA)
+	err := transactions.DistributeEth(ctx, TestAccountA, []*accounts.Account{spender}, big.NewInt(10000000000000000)) // 0.01 eth for gas
+	require.NoError(t, err)
+
+	_, _, err = transactions.Mint(ctx, TestAccountA, amount, TokenABI)
+	require.NoError(t, err)
+	_, _, err = transactions.Approve(ctx, TestAccountA, spender.GetAddress(), amount, TokenABI)
+	require.NoError(t, err)
+
+	initialBalanceOwner, err := TestAccountA.GetTokensBalance(ctx, tokenAddress, TokenABI)
+	require.NoError(t, err)
+
+	_, _, err = transactions.TransferFromToken(ctx, spender, tokenAddress, TestAccountA.GetAddress(), spender.GetAddress(), amount, TokenABI)
+	require.NoError(t, err)
+
+	balanceOwner, err := TestAccountA.GetTokensBalance(ctx, tokenAddress, TokenABI)
+	require.NoError(t, err)
+	balanceSpender, err := spender.GetTokensBalance(ctx, tokenAddress, TokenABI)
+	require.NoError(t, err)
+	require.Equal(t, new(big.Int).Sub(initialBalanceOwner, amount), balanceOwner)
+	require.Equal(t, amount, balanceSpender)
+}
+
+// newRandomAccount creates an account with a freshly generated key on the given rollup
+func newRandomAccount(t *testing.T, onRollup *rollup.Rollup) *accounts.Account {
+	t.Helper()
+	pk, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	ac, err := accounts.NewRollupAccount(hex.EncodeToString(crypto.FromECDSA(pk)), onRollup)
+	require.NoError(t, err)
+	t.Cleanup(ac.Close)
+	return ac
+}
+
+/*
+TestGetTokenAllowance approves an exact amount for a fresh spender and reads it back
+*/
+func TestGetTokenAllowance(t *testing.T) {
+	ctx := t.Context()
+	tokenAddress := configs.Values.L2.Contracts[configs.ContractNameToken].Address
+	amount := big.NewInt(123456789)
+
+	spender := newRandomAccount(t, TestRollupA)
+
+	_, _, err := transactions.Approve(ctx, TestAccountA, spender.GetAddress(), amount, TokenABI)
+	require.NoError(t, err)
+
+	allowance, err := TestAccountA.GetTokenAllowance(ctx, tokenAddress, spender.GetAddress(), TokenABI)
+	require.NoError(t, err)
+	require.Equal(t, amount, allowance)
+}
+
+/*
+TestBridgeConsumesExactAllowance approves exactly the bridged amount from a fresh account on rollup A and bridges it to B
+  - check that the bridge spends the whole allowance, leaving it at zero
+*/
+func TestBridgeConsumesExactAllowance(t *testing.T) {
+	ctx := t.Context()
+	tokenAddress := configs.Values.L2.Contracts[configs.ContractNameToken].Address
+	bridgeAddress := configs.Values.L2.Contracts[configs.ContractNameBridge].Address
+	amount := big.NewInt(100000000000000000) // 0.1 tokens
+
+	account := newRandomMultiChainAccount(t)
+	sender, receiver := account.On(TestRollupA), account.On(TestRollupB)
+	require.NoError(t, transactions.DistributeEth(ctx, TestAccountA, []*accounts.Account{sender}, big.NewInt(10000000000000000))) // 0.01 eth for gas
+	require.NoError(t, transactions.DistributeEth(ctx, TestAccountB, []*accounts.Account{receiver}, big.NewInt(10000000000000000)))
+
+	_, _, err := helpers.SendMintTx(t, sender, amount, TokenABI)
+	require.NoError(t, err)
+	_, _, err = helpers.ApproveTokensAmount(t, sender, bridgeAddress, amount, TokenABI)
+	require.NoError(t, err)
+	require.NoError(t, helpers.CheckBridgeSendPreconditions(ctx, sender, amount, TokenABI))
+
+	txA, txB, _, _, err := helpers.SendBridgeTx(t, sender, receiver, amount, TokenABI, BridgeABI)
+	require.NoError(t, err)
+	helpers.RequireTxSuccess(t, ctx, txA, TestRollupA)
+	helpers.RequireTxSuccess(t, ctx, txB, TestRollupB)
+
+	allowance, err := sender.GetTokenAllowance(ctx, tokenAddress, bridgeAddress, TokenABI)
+	require.NoError(t, err)
+	require.Zero(t, allowance.Sign())
+	require.ErrorIs(t, helpers.CheckBridgeSendPreconditions(ctx, sender, amount, TokenABI), helpers.ErrInsufficientTokenBalance)
+}
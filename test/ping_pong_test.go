@@ -7,9 +7,9 @@ import (
 	"time"
 
 	"github.com/compose-network/dome/configs"
+	"github.com/compose-network/dome/internal/helpers"
 	"github.com/compose-network/dome/internal/logger"
 	"github.com/compose-network/dome/internal/transactions"
-	"github.com/ethereum/go-ethereum/core/types"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -23,13 +23,8 @@ func TestPingPong(t *testing.T) {
 	pingPongAddress := configs.Values.L2.Contracts[configs.ContractNamePingPong].Address
 
 	// construct calldata on rollup A
-	calldataA, err := pingPongABI.Pack("ping",
-		TestRollupB.ChainID(),         // otherChain
-		TestAccountA.GetAddress(),     // pongSender
-		TestAccountB.GetAddress(),     // pingReceiver
-		sessionID,                     // sessionId
-		[]byte("Hello from rollup A"), // data
-	)
+	calldataA, err := helpers.BuildPing(TestRollupB.ChainID(), TestAccountA.GetAddress(), TestAccountB.GetAddress(),
+		sessionID, []byte("Hello from rollup A"), pingPongABI)
 	require.NoError(t, err)
 	require.NotNil(t, calldataA)
 
@@ -38,8 +33,8 @@ func TestPingPong(t *testing.T) {
 		To:        pingPongAddress,
 		Value:     big.NewInt(0),
 		Gas:       900000,
-		GasTipCap: big.NewInt(1000000000),
-		GasFeeCap: big.NewInt(20000000000),
+		GasTipCap: transactions.GWei(1),
+		GasFeeCap: transactions.GWei(20),
 		Data:      calldataA,
 	}
 	// create transaction to be sent from accountA
@@ -49,12 +44,8 @@ func TestPingPong(t *testing.T) {
 	// preparations for tx A done -------------------------------------------------------------
 
 	// construct calldata on rollup B
-	calldataB, err := pingPongABI.Pack("pong",
-		TestRollupA.ChainID(),         // otherChain
-		TestAccountB.GetAddress(),     // pingSender
-		sessionID,                     // sessionId
-		[]byte("Hello from rollup B"), // data
-	)
+	calldataB, err := helpers.BuildPong(TestRollupA.ChainID(), TestAccountB.GetAddress(), sessionID,
+		[]byte("Hello from rollup B"), pingPongABI)
 	require.NoError(t, err)
 	require.NotNil(t, calldataB)
 
@@ -63,8 +54,8 @@ func TestPingPong(t *testing.T) {
 		To:        pingPongAddress,
 		Value:     big.NewInt(0),
 		Gas:       900000,
-		GasTipCap: big.NewInt(1000000000),
-		GasFeeCap: big.NewInt(20000000000),
+		GasTipCap: transactions.GWei(1),
+		GasFeeCap: transactions.GWei(20),
 		Data:      calldataB,
 	}
 	// create transaction to be sent from accountB
@@ -78,13 +69,17 @@ func TestPingPong(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, crossTxRequestMsg)
 
+	fromBlock, err := TestRollupA.BlockNumber(ctx)
+	require.NoError(t, err)
+
 	// send cross tx request msg
-	err = transactions.SendCrossTxRequestMsg(ctx, TestRollupA.RPCURL(), crossTxRequestMsg)
+	_, err = transactions.SendCrossTxRequestMsg(ctx, TestRollupA.RPCURL(), crossTxRequestMsg)
 	require.NoError(t, err)
 
-	// wait for 2 minutes before checking txs
-	logger.Info("Waiting for 2 minutes before checking txs...")
-	time.Sleep(2 * time.Minute)
+	// wait for the ping to be emitted on rollup A before checking txs
+	logger.Info("Waiting up to 2 minutes for the PING event...")
+	_, err = transactions.WaitForEvent(ctx, TestRollupA, pingPongAddress, pingPongABI.Events["PING"].ID, fromBlock, 2*time.Minute)
+	require.NoError(t, err)
 
 	// check tx A
 	tx, receipt, err := transactions.GetTransactionDetails(ctx, txA.Hash(), TestRollupA)
@@ -92,7 +87,7 @@ func TestPingPong(t *testing.T) {
 	require.NotNil(t, tx)
 	require.NotNil(t, receipt)
 	// check tx is successful
-	assert.Equal(t, receipt.Status, types.ReceiptStatusSuccessful)
+	helpers.RequireTxSuccess(t, ctx, tx, TestRollupA)
 	// check that calldata and receiver are not malformed
 	assert.Equal(t, *tx.To(), pingPongAddress)
 	assert.True(t, bytes.Equal(tx.Data(), calldataA))
@@ -100,10 +95,9 @@ func TestPingPong(t *testing.T) {
 	// Find the pong event in the logs
 	for _, log := range receipt.Logs {
 		if len(log.Topics) > 0 && log.Topics[0] == pingPongABI.Events["PING"].ID {
-			// Decode the event data
-			eventData, err := pingPongABI.Unpack("PING", log.Data)
+			event, err := helpers.DecodePingEvent(log, pingPongABI)
 			require.NoError(t, err)
-			assert.Equal(t, eventData[0], "PONG")
+			assert.Equal(t, "PONG", event.Message)
 			break
 		}
 	}
@@ -114,7 +108,7 @@ func TestPingPong(t *testing.T) {
 	require.NotNil(t, tx)
 	require.NotNil(t, receipt)
 	// check tx is successful
-	assert.Equal(t, receipt.Status, types.ReceiptStatusSuccessful)
+	helpers.RequireTxSuccess(t, ctx, tx, TestRollupB)
 	// check that calldata and receiver are not malformed
 	assert.Equal(t, *tx.To(), pingPongAddress)
 	assert.True(t, bytes.Equal(tx.Data(), calldataB))
@@ -122,10 +116,9 @@ func TestPingPong(t *testing.T) {
 	// Find the ping event in the logs
 	for _, log := range receipt.Logs {
 		if len(log.Topics) > 0 && log.Topics[0] == pingPongABI.Events["PONG"].ID {
-			// Decode the event data
-			eventData, err := pingPongABI.Unpack("PONG", log.Data)
+			event, err := helpers.DecodePongEvent(log, pingPongABI)
 			require.NoError(t, err)
-			assert.Equal(t, eventData[0], "PING")
+			assert.Equal(t, "PING", event.Message)
 			break
 		}
 	}
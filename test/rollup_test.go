@@ -0,0 +1,29 @@
+package test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+/*
+TestBlockNumberIsMonotonic reads the chain head of every rollup twice
+  - check that the head never moves backwards and matches the header returned for that block
+*/
+func TestBlockNumberIsMonotonic(t *testing.T) {
+	ctx := t.Context()
+
+	for name, r := range TestRollups {
+		first, err := r.BlockNumber(ctx)
+		require.NoError(t, err, "rollup %s", name)
+
+		header, err := r.HeaderByNumber(ctx, new(big.Int).SetUint64(first))
+		require.NoError(t, err, "rollup %s", name)
+		require.Equal(t, first, header.Number.Uint64())
+
+		second, err := r.BlockNumber(ctx)
+		require.NoError(t, err, "rollup %s", name)
+		require.GreaterOrEqual(t, second, first, "head of rollup %s moved backwards", name)
+	}
+}
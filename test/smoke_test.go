@@ -3,11 +3,12 @@ package test
 import (
 	"bytes"
 	"math/big"
-	"sync"
 	"testing"
 
 	"github.com/compose-network/dome/configs"
+	"github.com/compose-network/dome/internal/helpers"
 	"github.com/compose-network/dome/internal/transactions"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -18,6 +19,19 @@ var (
 	transferredAmount = big.NewInt(100000000000000000)  // 0.1 tokens
 )
 
+// requireTokenTransfer asserts that receipt contains a token Transfer event of amount whose field ("from" or "to") is account
+func requireTokenTransfer(t *testing.T, receipt *types.Receipt, field string, account common.Address, amount *big.Int) {
+	t.Helper()
+	transfers, err := helpers.DecodeEvents(receipt, TokenABI, "Transfer")
+	require.NoError(t, err)
+	for _, transfer := range transfers {
+		if transfer[field] == account && amount.Cmp(transfer["value"].(*big.Int)) == 0 {
+			return
+		}
+	}
+	t.Fatalf("no Transfer of %s with %s %s in tx %s", amount, field, account.Hex(), receipt.TxHash.Hex())
+}
+
 /*
 TestMintTokensCrossRollup tests the minting of tokens on both chains and sends the txs as cross rollup tx
 */
@@ -44,8 +58,8 @@ func TestMintTokensCrossRollup(t *testing.T) {
 		To:        tokenAddress,
 		Value:     big.NewInt(0),
 		Gas:       900000,
-		GasTipCap: big.NewInt(1000000000),
-		GasFeeCap: big.NewInt(20000000000),
+		GasTipCap: transactions.GWei(1),
+		GasFeeCap: transactions.GWei(20),
 		Data:      calldataA,
 	}
 
@@ -68,8 +82,8 @@ func TestMintTokensCrossRollup(t *testing.T) {
 		To:        tokenAddress,
 		Value:     big.NewInt(0),
 		Gas:       900000,
-		GasTipCap: big.NewInt(1000000000),
-		GasFeeCap: big.NewInt(20000000000),
+		GasTipCap: transactions.GWei(1),
+		GasFeeCap: transactions.GWei(20),
 		Data:      calldataB,
 	}
 
@@ -84,61 +98,19 @@ func TestMintTokensCrossRollup(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, crossTxRequestMsg)
 
-	// send cross tx request msg
-	err = transactions.SendCrossTxRequestMsg(ctx, TestRollupA.RPCURL(), crossTxRequestMsg)
+	// send cross tx request msg and wait for both legs
+	minedTxs, _, err := transactions.SendCrossTxAndWait(ctx, TestRollupA.RPCURL(), crossTxRequestMsg, []transactions.WaitLeg{
+		{TxHash: txA.Hash(), Rollup: TestRollupA},
+		{TxHash: txB.Hash(), Rollup: TestRollupB},
+	})
 	require.NoError(t, err)
+	minedA, minedB := minedTxs[0], minedTxs[1]
 
-	// Check tx A and tx B in parallel
-	type txResult struct {
-		tx      *types.Transaction
-		receipt *types.Receipt
-		err     error
-	}
-
-	var wg sync.WaitGroup
-	resultA := make(chan txResult, 1)
-	resultB := make(chan txResult, 1)
-
-	// Check tx A
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		tx, receipt, err := transactions.GetTransactionDetails(ctx, txA.Hash(), TestRollupA)
-		resultA <- txResult{tx: tx, receipt: receipt, err: err}
-	}()
-
-	// Check tx B
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		tx, receipt, err := transactions.GetTransactionDetails(ctx, txB.Hash(), TestRollupB)
-		resultB <- txResult{tx: tx, receipt: receipt, err: err}
-	}()
-
-	// Wait for both goroutines to complete
-	wg.Wait()
-
-	// Get results for tx A
-	resA := <-resultA
-	require.NoError(t, resA.err)
-	require.NotNil(t, resA.tx)
-	require.NotNil(t, resA.receipt)
-	// check tx is successful
-	assert.Equal(t, resA.receipt.Status, types.ReceiptStatusSuccessful)
-	// check that calldata and receiver are not malformed
-	assert.Equal(t, *resA.tx.To(), tokenAddress)
-	assert.True(t, bytes.Equal(resA.tx.Data(), calldataA))
-
-	// Get results for tx B
-	resB := <-resultB
-	require.NoError(t, resB.err)
-	require.NotNil(t, resB.tx)
-	require.NotNil(t, resB.receipt)
-	// check tx is successful
-	assert.Equal(t, resB.receipt.Status, types.ReceiptStatusSuccessful)
-	// check that calldata and receiver are not malformed
-	assert.Equal(t, *resB.tx.To(), tokenAddress)
-	assert.True(t, bytes.Equal(resB.tx.Data(), calldataB))
+	// check that calldata and receiver of the mined txs are not malformed
+	assert.Equal(t, tokenAddress, *minedA.To())
+	assert.True(t, bytes.Equal(calldataA, minedA.Data()))
+	assert.Equal(t, tokenAddress, *minedB.To())
+	assert.True(t, bytes.Equal(calldataB, minedB.Data()))
 
 	// check balances after txs
 	tokenBalanceAAfter, err := TestAccountA.GetTokensBalance(ctx, tokenAddress, TokenABI)
@@ -191,8 +163,8 @@ func TestSendCrossTxBridgeFromAToB(t *testing.T) {
 		To:        bridgeAddr,
 		Value:     big.NewInt(0),
 		Gas:       900000,
-		GasTipCap: big.NewInt(1000000000),
-		GasFeeCap: big.NewInt(20000000000),
+		GasTipCap: transactions.GWei(1),
+		GasFeeCap: transactions.GWei(20),
 		Data:      calldataA,
 	}
 
@@ -218,8 +190,8 @@ func TestSendCrossTxBridgeFromAToB(t *testing.T) {
 		To:        bridgeAddr,
 		Value:     big.NewInt(0),
 		Gas:       900000,
-		GasTipCap: big.NewInt(1000000000),
-		GasFeeCap: big.NewInt(20000000000),
+		GasTipCap: transactions.GWei(1),
+		GasFeeCap: transactions.GWei(20),
 		Data:      calldataB,
 	}
 
@@ -234,61 +206,24 @@ func TestSendCrossTxBridgeFromAToB(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, crossTxRequestMsg)
 
-	// send cross tx request msg to source chain (A)
-	err = transactions.SendCrossTxRequestMsg(ctx, TestRollupA.RPCURL(), crossTxRequestMsg)
+	// send cross tx request msg to source chain (A) and wait for both legs
+	minedTxs, receipts, err := transactions.SendCrossTxAndWait(ctx, TestRollupA.RPCURL(), crossTxRequestMsg, []transactions.WaitLeg{
+		{TxHash: txA.Hash(), Rollup: TestRollupA},
+		{TxHash: txB.Hash(), Rollup: TestRollupB},
+	})
 	require.NoError(t, err)
+	minedA, minedB := minedTxs[0], minedTxs[1]
+	receiptA, receiptB := receipts[0], receipts[1]
 
-	// Check tx A and tx B in parallel
-	type txResult struct {
-		tx      *types.Transaction
-		receipt *types.Receipt
-		err     error
-	}
+	// check that calldata and receiver of the mined txs are not malformed
+	assert.Equal(t, bridgeAddr, *minedA.To())
+	assert.True(t, bytes.Equal(calldataA, minedA.Data()))
+	assert.Equal(t, bridgeAddr, *minedB.To())
+	assert.True(t, bytes.Equal(calldataB, minedB.Data()))
 
-	var wg sync.WaitGroup
-	resultA := make(chan txResult, 1)
-	resultB := make(chan txResult, 1)
-
-	// Check tx A
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		tx, receipt, err := transactions.GetTransactionDetails(ctx, txA.Hash(), TestRollupA)
-		resultA <- txResult{tx: tx, receipt: receipt, err: err}
-	}()
-
-	// Check tx B
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		tx, receipt, err := transactions.GetTransactionDetails(ctx, txB.Hash(), TestRollupB)
-		resultB <- txResult{tx: tx, receipt: receipt, err: err}
-	}()
-
-	// Wait for both goroutines to complete
-	wg.Wait()
-
-	// Get results for tx A
-	resA := <-resultA
-	require.NoError(t, resA.err)
-	require.NotNil(t, resA.tx)
-	require.NotNil(t, resA.receipt)
-	// check tx is successful
-	assert.Equal(t, resA.receipt.Status, types.ReceiptStatusSuccessful)
-	// check that calldata and receiver are not malformed
-	assert.Equal(t, *resA.tx.To(), bridgeAddr)
-	assert.True(t, bytes.Equal(resA.tx.Data(), calldataA))
-
-	// Get results for tx B
-	resB := <-resultB
-	require.NoError(t, resB.err)
-	require.NotNil(t, resB.tx)
-	require.NotNil(t, resB.receipt)
-	// check tx is successful
-	assert.Equal(t, resB.receipt.Status, types.ReceiptStatusSuccessful)
-	// check that calldata and receiver are not malformed
-	assert.Equal(t, *resB.tx.To(), bridgeAddr)
-	assert.True(t, bytes.Equal(resB.tx.Data(), calldataB))
+	// check the bridged amount in the emitted token transfers
+	requireTokenTransfer(t, receiptA, "from", TestAccountA.GetAddress(), transferredAmount)
+	requireTokenTransfer(t, receiptB, "to", TestAccountB.GetAddress(), transferredAmount)
 
 	// check balances after txs
 	tokenBalanceAAfter, err := TestAccountA.GetTokensBalance(ctx, tokenAddress, TokenABI)
@@ -341,8 +276,8 @@ func TestSendCrossTxBridgeFromBToA(t *testing.T) {
 		To:        bridgeAddr,
 		Value:     big.NewInt(0),
 		Gas:       900000,
-		GasTipCap: big.NewInt(1000000000),
-		GasFeeCap: big.NewInt(20000000000),
+		GasTipCap: transactions.GWei(1),
+		GasFeeCap: transactions.GWei(20),
 		Data:      calldataB,
 	}
 
@@ -368,8 +303,8 @@ func TestSendCrossTxBridgeFromBToA(t *testing.T) {
 		To:        bridgeAddr,
 		Value:     big.NewInt(0),
 		Gas:       900000,
-		GasTipCap: big.NewInt(1000000000),
-		GasFeeCap: big.NewInt(20000000000),
+		GasTipCap: transactions.GWei(1),
+		GasFeeCap: transactions.GWei(20),
 		Data:      calldataA,
 	}
 
@@ -384,61 +319,24 @@ func TestSendCrossTxBridgeFromBToA(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, crossTxRequestMsg)
 
-	// send cross tx request msg to source chain (B)
-	err = transactions.SendCrossTxRequestMsg(ctx, TestRollupB.RPCURL(), crossTxRequestMsg)
+	// send cross tx request msg to source chain (B) and wait for both legs
+	minedTxs, receipts, err := transactions.SendCrossTxAndWait(ctx, TestRollupB.RPCURL(), crossTxRequestMsg, []transactions.WaitLeg{
+		{TxHash: txA.Hash(), Rollup: TestRollupA},
+		{TxHash: txB.Hash(), Rollup: TestRollupB},
+	})
 	require.NoError(t, err)
+	minedA, minedB := minedTxs[0], minedTxs[1]
+	receiptA, receiptB := receipts[0], receipts[1]
 
-	// Check tx A and tx B in parallel
-	type txResult struct {
-		tx      *types.Transaction
-		receipt *types.Receipt
-		err     error
-	}
+	// check that calldata and receiver of the mined txs are not malformed
+	assert.Equal(t, bridgeAddr, *minedB.To())
+	assert.True(t, bytes.Equal(calldataB, minedB.Data()))
+	assert.Equal(t, bridgeAddr, *minedA.To())
+	assert.True(t, bytes.Equal(calldataA, minedA.Data()))
 
-	var wg sync.WaitGroup
-	resultA := make(chan txResult, 1)
-	resultB := make(chan txResult, 1)
-
-	// Check tx A
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		tx, receipt, err := transactions.GetTransactionDetails(ctx, txA.Hash(), TestRollupA)
-		resultA <- txResult{tx: tx, receipt: receipt, err: err}
-	}()
-
-	// Check tx B
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		tx, receipt, err := transactions.GetTransactionDetails(ctx, txB.Hash(), TestRollupB)
-		resultB <- txResult{tx: tx, receipt: receipt, err: err}
-	}()
-
-	// Wait for both goroutines to complete
-	wg.Wait()
-
-	// Get results for tx B
-	resB := <-resultB
-	require.NoError(t, resB.err)
-	require.NotNil(t, resB.tx)
-	require.NotNil(t, resB.receipt)
-	// check tx is successful
-	assert.Equal(t, resB.receipt.Status, types.ReceiptStatusSuccessful)
-	// check that calldata and receiver are not malformed
-	assert.Equal(t, *resB.tx.To(), bridgeAddr)
-	assert.True(t, bytes.Equal(resB.tx.Data(), calldataB))
-
-	// Get results for tx A
-	resA := <-resultA
-	require.NoError(t, resA.err)
-	require.NotNil(t, resA.tx)
-	require.NotNil(t, resA.receipt)
-	// check tx is successful
-	assert.Equal(t, resA.receipt.Status, types.ReceiptStatusSuccessful)
-	// check that calldata and receiver are not malformed
-	assert.Equal(t, *resA.tx.To(), bridgeAddr)
-	assert.True(t, bytes.Equal(resA.tx.Data(), calldataA))
+	// check the bridged amount in the emitted token transfers
+	requireTokenTransfer(t, receiptB, "from", TestAccountB.GetAddress(), transferredAmount)
+	requireTokenTransfer(t, receiptA, "to", TestAccountA.GetAddress(), transferredAmount)
 
 	// check balances after txs
 	tokenBalanceBAfter, err := TestAccountB.GetTokensBalance(ctx, tokenAddress, TokenABI)
@@ -492,8 +390,8 @@ func TestSendOnAAndFailingSelfMoveBalanceOnB(t *testing.T) {
 		To:        bridgeAddr,
 		Value:     big.NewInt(0),
 		Gas:       900000,
-		GasTipCap: big.NewInt(1000000000),
-		GasFeeCap: big.NewInt(20000000000),
+		GasTipCap: transactions.GWei(1),
+		GasFeeCap: transactions.GWei(20),
 		Data:      calldataA,
 	}
 
@@ -513,8 +411,8 @@ func TestSendOnAAndFailingSelfMoveBalanceOnB(t *testing.T) {
 		To:        TestAccountB.GetAddress(),
 		Value:     balanceB.Add(balanceB, big.NewInt(100000)), // more than balanceB
 		Gas:       900000,
-		GasTipCap: big.NewInt(1000000000),
-		GasFeeCap: big.NewInt(20000000000),
+		GasTipCap: transactions.GWei(1),
+		GasFeeCap: transactions.GWei(20),
 		Data:      nil,
 	}
 
@@ -529,17 +427,14 @@ func TestSendOnAAndFailingSelfMoveBalanceOnB(t *testing.T) {
 	require.NotNil(t, crossTxRequestMsg)
 
 	// send cross tx request msg
-	err = transactions.SendCrossTxRequestMsg(ctx, TestRollupA.RPCURL(), crossTxRequestMsg)
+	_, err = transactions.SendCrossTxRequestMsg(ctx, TestRollupA.RPCURL(), crossTxRequestMsg)
 	require.NoError(t, err)
 
 	// neither tx should be sent to the chain
-	_, _, err = transactions.GetTransactionDetails(ctx, txA.Hash(), TestRollupA)
-	require.Error(t, err)
-	assert.Contains(t, err.Error(), "transaction receipt not found after 10 retries for hash")
-
-	_, _, err = transactions.GetTransactionDetails(ctx, txB.Hash(), TestRollupB)
-	require.Error(t, err)
-	assert.Contains(t, err.Error(), "transaction receipt not found after 10 retries for hash")
+	var batch transactions.BatchResult
+	batch.Add(txA, TestRollupA, transactions.ExpectNotIncluded)
+	batch.Add(txB, TestRollupB, transactions.ExpectNotIncluded)
+	verifyBatch(t, &batch, notIncludedTimeout)
 
 	// token balance on A should be the same as before
 	tokenBalanceAAfter, err := TestAccountA.GetTokensBalance(ctx, tokenAddress, TokenABI)
@@ -594,8 +489,8 @@ func TestSendCrossTxBridgeWithOutOfGasOnB(t *testing.T) {
 		To:        bridgeAddr,
 		Value:     big.NewInt(0),
 		Gas:       900000,
-		GasTipCap: big.NewInt(1000000000),
-		GasFeeCap: big.NewInt(20000000000),
+		GasTipCap: transactions.GWei(1),
+		GasFeeCap: transactions.GWei(20),
 		Data:      calldataA,
 	}
 
@@ -621,8 +516,8 @@ func TestSendCrossTxBridgeWithOutOfGasOnB(t *testing.T) {
 		To:        bridgeAddr,
 		Value:     big.NewInt(0),
 		Gas:       300000, // 318,316 gas is needed
-		GasTipCap: big.NewInt(1000000000),
-		GasFeeCap: big.NewInt(20000000000),
+		GasTipCap: transactions.GWei(1),
+		GasFeeCap: transactions.GWei(20),
 		Data:      calldataB,
 	}
 
@@ -638,17 +533,14 @@ func TestSendCrossTxBridgeWithOutOfGasOnB(t *testing.T) {
 	require.NotNil(t, crossTxRequestMsg)
 
 	// send cross tx request msg
-	err = transactions.SendCrossTxRequestMsg(ctx, TestRollupA.RPCURL(), crossTxRequestMsg)
+	_, err = transactions.SendCrossTxRequestMsg(ctx, TestRollupA.RPCURL(), crossTxRequestMsg)
 	require.NoError(t, err)
 
 	// neither tx should be sent to the chain
-	_, _, err = transactions.GetTransactionDetails(ctx, txA.Hash(), TestRollupA)
-	require.Error(t, err)
-	assert.Contains(t, err.Error(), "transaction receipt not found after 10 retries for hash")
-
-	_, _, err = transactions.GetTransactionDetails(ctx, txB.Hash(), TestRollupB)
-	require.Error(t, err)
-	assert.Contains(t, err.Error(), "transaction receipt not found after 10 retries for hash")
+	var batch transactions.BatchResult
+	batch.Add(txA, TestRollupA, transactions.ExpectNotIncluded)
+	batch.Add(txB, TestRollupB, transactions.ExpectNotIncluded)
+	verifyBatch(t, &batch, notIncludedTimeout)
 
 	// check balances after txs
 	tokenBalanceAAfter, err := TestAccountA.GetTokensBalance(ctx, tokenAddress, TokenABI)
@@ -682,8 +574,8 @@ func TestSelfMoveBalanceOnAandreceiveTokensOnB(t *testing.T) {
 		To:        TestAccountA.GetAddress(),
 		Value:     big.NewInt(500000000000000000), // 0.5 eth
 		Gas:       900000,
-		GasTipCap: big.NewInt(1000000000),
-		GasFeeCap: big.NewInt(20000000000),
+		GasTipCap: transactions.GWei(1),
+		GasFeeCap: transactions.GWei(20),
 		Data:      nil, // no data needed for self move balance
 	}
 
@@ -709,8 +601,8 @@ func TestSelfMoveBalanceOnAandreceiveTokensOnB(t *testing.T) {
 		To:        bridgeAddr,
 		Value:     big.NewInt(0),
 		Gas:       900000,
-		GasTipCap: big.NewInt(1000000000),
-		GasFeeCap: big.NewInt(20000000000),
+		GasTipCap: transactions.GWei(1),
+		GasFeeCap: transactions.GWei(20),
 		Data:      calldataB,
 	}
 
@@ -726,16 +618,14 @@ func TestSelfMoveBalanceOnAandreceiveTokensOnB(t *testing.T) {
 	require.NotNil(t, crossTxRequestMsg)
 
 	// send cross tx request msg
-	err = transactions.SendCrossTxRequestMsg(ctx, TestRollupA.RPCURL(), crossTxRequestMsg)
+	_, err = transactions.SendCrossTxRequestMsg(ctx, TestRollupA.RPCURL(), crossTxRequestMsg)
 	require.NoError(t, err)
 
 	// neither of txs should be processed
-	_, _, err = transactions.GetTransactionDetails(ctx, txA.Hash(), TestRollupA)
-	require.Error(t, err)
-	assert.Contains(t, err.Error(), "transaction receipt not found after 10 retries for hash")
-	_, _, err = transactions.GetTransactionDetails(ctx, txB.Hash(), TestRollupB)
-	require.Error(t, err)
-	assert.Contains(t, err.Error(), "transaction receipt not found after 10 retries for hash")
+	var batch transactions.BatchResult
+	batch.Add(txA, TestRollupA, transactions.ExpectNotIncluded)
+	batch.Add(txB, TestRollupB, transactions.ExpectNotIncluded)
+	verifyBatch(t, &batch, notIncludedTimeout)
 
 	// check balances after txs
 	balanceAAfter, err := TestAccountA.GetBalance(ctx)
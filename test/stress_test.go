@@ -1,8 +1,10 @@
 package test
 
 import (
+	"context"
 	"encoding/hex"
 	"math/big"
+	"sync"
 	"testing"
 	"time"
 
@@ -11,7 +13,7 @@ import (
 	"github.com/compose-network/dome/internal/helpers"
 	"github.com/compose-network/dome/internal/logger"
 	"github.com/compose-network/dome/internal/transactions"
-	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/require"
 )
@@ -26,8 +28,85 @@ const (
 	numOfAccountsForMultipleTxs = 5 // number of accounts to be spawned in parallel
 	// general delay between cross-rollup txs
 	delay = 100 * time.Millisecond // delay between txs
+	// how long a batch may take until every tx is mined
+	receiptTimeout = 2 * time.Minute
+	// how long a tx that must not be included is watched for before it counts as dropped
+	notIncludedTimeout = 15 * time.Second
 )
 
+// verifyBatch waits up to timeout for every tx of batch and asserts its expected outcome
+func verifyBatch(t *testing.T, batch *transactions.BatchResult, timeout time.Duration) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(t.Context(), timeout)
+	defer cancel()
+	batch.Verify(ctx, t)
+}
+
+// newRandomMultiChainAccount creates an account for a fresh key on both test rollups
+func newRandomMultiChainAccount(t *testing.T) *accounts.MultiChainAccount {
+	t.Helper()
+	pk, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	account, err := accounts.NewMultiChainAccount(hex.EncodeToString(crypto.FromECDSA(pk)), TestRollupA, TestRollupB)
+	require.NoError(t, err)
+	return account
+}
+
+var (
+	stressPoolOnce sync.Once
+	stressPool     *accounts.Pool
+	stressPoolErr  error
+)
+
+// acquirePooledAccount takes an account funded with eth and bridge approvals on both test rollups from a pool shared
+// by the stress tests, and releases it when the test ends. The pool is funded on first use.
+func acquirePooledAccount(t *testing.T) *accounts.MultiChainAccount {
+	t.Helper()
+	stressPoolOnce.Do(func() {
+		stressPool, stressPoolErr = accounts.NewPool(context.Background(), numOfAccounts, fundPoolAccounts, TestRollupA, TestRollupB)
+	})
+	require.NoError(t, stressPoolErr)
+
+	account, err := stressPool.Acquire(t.Context())
+	require.NoError(t, err)
+	t.Cleanup(func() { stressPool.Release(account) })
+	return account
+}
+
+// fundPoolAccounts sends 0.1 eth for gas to every pool account on both rollups and approves the bridge for their tokens
+func fundPoolAccounts(ctx context.Context, pool []*accounts.MultiChainAccount) error {
+	bridgeAddress := configs.Values.L2.Contracts[configs.ContractNameBridge].Address
+	for _, sponsor := range []*accounts.Account{TestAccountA, TestAccountB} {
+		onRollup := make([]*accounts.Account, len(pool))
+		for i, account := range pool {
+			onRollup[i] = account.On(sponsor.GetRollup())
+		}
+		logger.Info("Distributing 0.1 eth to %d pool accounts on %s...", len(onRollup), sponsor.GetRollup().Name())
+		if err := transactions.DistributeEth(ctx, sponsor, onRollup, big.NewInt(100000000000000000)); err != nil {
+			return err
+		}
+		for _, ac := range onRollup {
+			if _, _, err := helpers.DefaultApproveTokens(ctx, ac, bridgeAddress, TokenABI); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// observeLatencies records submit-to-confirmation latencies for the rest of the test and logs p50/p95 when it ends
+func observeLatencies(t *testing.T) {
+	t.Helper()
+	observer := &transactions.LatencyObserver{}
+	transactions.SetObserver(observer)
+	t.Cleanup(func() {
+		transactions.SetObserver(nil)
+		submitted, confirmed, failed := observer.Counts()
+		logger.Info("%s: %d txs submitted, %d confirmed, %d failed, confirmation latency p50 %s, p95 %s",
+			t.Name(), submitted, confirmed, failed, observer.Percentile(50), observer.Percentile(95))
+	})
+}
+
 /*
 TestStressBridgeSameAccount will build numOfTxs transactions with the same account and send them to the bridge with delay.
 */
@@ -44,11 +123,9 @@ func TestStressBridgeSameAccount(t *testing.T) {
 	require.NotNil(t, tx)
 	require.NotNil(t, hash)
 
-	// get starting nonces for sender account
-	startingNonceA, err := TestAccountA.GetNonce(ctx)
-	require.NoError(t, err)
-	startingNonceB, err := TestAccountB.GetNonce(ctx)
-	require.NoError(t, err)
+	// sync nonce managers with the chain
+	require.NoError(t, TestAccountA.Nonces().Reset(ctx))
+	require.NoError(t, TestAccountB.Nonces().Reset(ctx))
 
 	// get initial balances
 	initialBalanceA, err := TestAccountA.GetTokensBalance(ctx, tokenAddress, TokenABI)
@@ -56,36 +133,30 @@ func TestStressBridgeSameAccount(t *testing.T) {
 	initialBalanceB, err := TestAccountB.GetTokensBalance(ctx, tokenAddress, TokenABI)
 	require.NoError(t, err)
 
-	var txs_A []*types.Transaction
-	var txs_B []*types.Transaction
+	var batch transactions.BatchResult
 
 	for i := 0; i < numOfTxs; i++ {
-		logger.Info("Creating set of txs with nonce %d and %d", startingNonceA+uint64(i), startingNonceB+uint64(i))
-		txA, txB, err := helpers.SendBridgeTxWithNonce(t, TestAccountA, startingNonceA+uint64(i), TestAccountB, startingNonceB+uint64(i), transferedAmount, TokenABI, BridgeABI)
-		txs_A = append(txs_A, txA)
-		txs_B = append(txs_B, txB)
+		nonceA, err := TestAccountA.Nonces().Next(ctx)
+		require.NoError(t, err)
+		nonceB, err := TestAccountB.Nonces().Next(ctx)
+		require.NoError(t, err)
+		logger.Info("Creating set of txs with nonce %d and %d", nonceA, nonceB)
+		txA, txB, _, _, err := helpers.SendBridgeTxWithNonce(t, TestAccountA, nonceA, TestAccountB, nonceB, transferedAmount, TokenABI, BridgeABI)
 		require.NoError(t, err)
 		require.NotNil(t, txA)
 		require.NotNil(t, txB)
+		batch.Add(txA, TestRollupA, transactions.ExpectSuccess)
+		batch.Add(txB, TestRollupB, transactions.ExpectSuccess)
 		time.Sleep(delay)
 	}
 
-	// check the txs
-	logger.Info("Waiting 30s until we check the txs...")
-	time.Sleep(30 * time.Second)
-	for _, tx := range txs_A {
-		_, receipt, err := transactions.GetTransactionDetails(ctx, tx.Hash(), TestRollupA)
-		require.NoError(t, err)
-		require.NotNil(t, receipt)
-		require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
-
-	}
-	for _, tx := range txs_B {
-		_, receipt, err := transactions.GetTransactionDetails(ctx, tx.Hash(), TestRollupB)
-		require.NoError(t, err)
-		require.NotNil(t, receipt)
-		require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
-	}
+	// wait until all bridged tokens arrived on B before checking the txs
+	expectedSentAmount := new(big.Int).Mul(transferedAmount, big.NewInt(numOfTxs))
+	expectedBalanceB := new(big.Int).Add(initialBalanceB, expectedSentAmount)
+	logger.Info("Waiting up to 30s for the bridged tokens to settle...")
+	err = accounts.WaitForTokenBalance(ctx, TestAccountB, tokenAddress, TokenABI, expectedBalanceB, 30*time.Second)
+	require.NoError(t, err)
+	verifyBatch(t, &batch, receiptTimeout)
 
 	// check balances after txs
 	balanceAAfter, err := TestAccountA.GetTokensBalance(ctx, tokenAddress, TokenABI)
@@ -95,42 +166,27 @@ func TestStressBridgeSameAccount(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, balanceBAfter)
 
-	expectedSentAmount := new(big.Int).Mul(transferedAmount, big.NewInt(numOfTxs))
 	expectedBalanceA := new(big.Int).Sub(initialBalanceA, expectedSentAmount)
-	expectedBalanceB := new(big.Int).Add(initialBalanceB, expectedSentAmount)
 	require.Equal(t, expectedBalanceA, balanceAAfter)
 	require.Equal(t, expectedBalanceB, balanceBAfter)
 }
 
 /*
-TestStressBridgeDifferentAccounts will spawn <numOfAccounts> accounts on both rollups and send 1 transaction from each with delay between them.
+TestStressBridgeDifferentAccounts will take <numOfAccounts> pooled accounts on both rollups and send 1 transaction from each with delay between them.
 */
 func TestStressBridgeDifferentAccounts(t *testing.T) {
 	ctx := t.Context()
 	tokenAddress := configs.Values.L2.Contracts[configs.ContractNameToken].Address
-	bridgeAddress := configs.Values.L2.Contracts[configs.ContractNameBridge].Address
 
 	mintedAndTransferredAmount := big.NewInt(1000000000000000000) // 1 token
-	//spam x nr of accounts on both rollups
+	// pooled accounts already hold eth for gas and have approved the bridge
 	accountsOnRollupA := make([]*accounts.Account, numOfAccounts)
 	accountsOnRollupB := make([]*accounts.Account, numOfAccounts)
-	for i := 0; i < numOfAccounts; i++ {
-		pk, err := crypto.GenerateKey()
-		require.NoError(t, err)
-		pkHex := hex.EncodeToString(crypto.FromECDSA(pk))
-		accountsOnRollupA[i], err = accounts.NewRollupAccount(pkHex, TestRollupA)
-		require.NoError(t, err)
-		accountsOnRollupB[i], err = accounts.NewRollupAccount(pkHex, TestRollupB)
-		require.NoError(t, err)
+	for i := range numOfAccounts {
+		account := acquirePooledAccount(t)
+		accountsOnRollupA[i], accountsOnRollupB[i] = account.On(TestRollupA), account.On(TestRollupB)
 	}
 
-	//distribute 0.1 eth to all accounts for gass
-	logger.Info("Distributing 0.1 eth to all accounts...")
-	err := transactions.DistributeEth(ctx, TestAccountA, accountsOnRollupA, big.NewInt(100000000000000000))
-	require.NoError(t, err)
-	err = transactions.DistributeEth(ctx, TestAccountB, accountsOnRollupB, big.NewInt(100000000000000000))
-	require.NoError(t, err)
-
 	// mint tokens for A accounts
 	logger.Info("Minting tokens to all accounts...")
 	for _, acc := range accountsOnRollupA {
@@ -140,60 +196,44 @@ func TestStressBridgeDifferentAccounts(t *testing.T) {
 		require.NotNil(t, hash)
 	}
 
-	// approve tokens for the bridge contract
-	logger.Info("Approving tokens for the bridge contract...")
-	for _, acc := range accountsOnRollupA {
-		_, _, err := helpers.ApproveTokens(t, acc, bridgeAddress, TokenABI)
-		require.NoError(t, err)
-	}
+	// pooled accounts may hold tokens from earlier tests, so compare against the balances before bridging
+	snapshotA, err := accounts.NewBalanceSnapshot(ctx, tokenAddress, TokenABI, accountsOnRollupA...)
+	require.NoError(t, err)
+	snapshotB, err := accounts.NewBalanceSnapshot(ctx, tokenAddress, TokenABI, accountsOnRollupB...)
+	require.NoError(t, err)
 
-	var txs_A []*types.Transaction
-	var txs_B []*types.Transaction
+	var batch transactions.BatchResult
 	// send bridge txs from A to B with delay
 	for i := range len(accountsOnRollupA) {
-		txA, txB, err := helpers.SendBridgeTx(t, accountsOnRollupA[i], accountsOnRollupB[i], mintedAndTransferredAmount, TokenABI, BridgeABI)
-		txs_A = append(txs_A, txA)
-		txs_B = append(txs_B, txB)
+		txA, txB, _, _, err := helpers.SendBridgeTx(t, accountsOnRollupA[i], accountsOnRollupB[i], mintedAndTransferredAmount, TokenABI, BridgeABI)
 		require.NoError(t, err)
 		require.NotNil(t, txA)
 		require.NotNil(t, txB)
+		batch.Add(txA, TestRollupA, transactions.ExpectSuccess)
+		batch.Add(txB, TestRollupB, transactions.ExpectSuccess)
 		time.Sleep(delay)
 	}
 
-	logger.Info("Waiting 30s until we check the txs...")
-	time.Sleep(30 * time.Second)
-	for _, tx := range txs_A {
-		_, receipt, err := transactions.GetTransactionDetails(ctx, tx.Hash(), TestRollupA)
-		require.NoError(t, err)
-		require.NotNil(t, receipt)
-		require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status, "tx %s", tx.Hash().Hex())
-	}
-
-	for _, tx := range txs_B {
-		_, receipt, err := transactions.GetTransactionDetails(ctx, tx.Hash(), TestRollupB)
-		require.NoError(t, err)
-		require.NotNil(t, receipt)
-		require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status, "tx %s", tx.Hash().Hex())
-	}
+	verifyBatch(t, &batch, receiptTimeout)
 
-	// expected balances
-	for _, acc := range accountsOnRollupA {
-		balance, err := acc.GetTokensBalance(ctx, tokenAddress, TokenABI)
-		require.NoError(t, err)
-		require.Equal(t, 0, balance.Cmp(big.NewInt(0))) // on rollup A, all tokens should be sent to rollup B
-	}
-	for _, acc := range accountsOnRollupB {
-		balance, err := acc.GetTokensBalance(ctx, tokenAddress, TokenABI)
-		require.NoError(t, err)
-		require.Equal(t, 0, balance.Cmp(mintedAndTransferredAmount)) // on rollup B, all tokens should be received from rollup A
+	// expected balances: on rollup A all minted tokens were sent to rollup B, where they were all received
+	sentAmount := new(big.Int).Neg(mintedAndTransferredAmount)
+	expectedA := make(map[common.Address]accounts.Delta, len(accountsOnRollupA))
+	expectedB := make(map[common.Address]accounts.Delta, len(accountsOnRollupB))
+	for i := range accountsOnRollupA {
+		expectedA[accountsOnRollupA[i].GetAddress()] = accounts.Delta{Token: sentAmount}
+		expectedB[accountsOnRollupB[i].GetAddress()] = accounts.Delta{Token: mintedAndTransferredAmount}
 	}
+	snapshotA.AssertDeltas(t, expectedA)
+	snapshotB.AssertDeltas(t, expectedB)
 }
 
 /*
-TestStressMultipleAccountsAndMultipleTxs will spawn <numOfAccounts> accounts on both rollups and send <numOfTxs> transactions with <delay> between them.
-The txs will be sent in parallel up to <maxNumOfTxsInParalel> txs at a time.
+TestStressMultipleAccountsAndMultipleTxs will spawn <numOfAccountsForMultipleTxs> accounts on both rollups and send <numOfTxsForMultipleAccounts> transactions from each.
+The txs will be sent in parallel up to <numOfAccountsForMultipleTxs> txs at a time.
 */
 func TestStressMultipleAccountsAndMultipleTxs(t *testing.T) {
+	observeLatencies(t)
 	ctx := t.Context()
 	tokenAddress := configs.Values.L2.Contracts[configs.ContractNameToken].Address
 	bridgeAddress := configs.Values.L2.Contracts[configs.ContractNameBridge].Address
@@ -202,21 +242,25 @@ func TestStressMultipleAccountsAndMultipleTxs(t *testing.T) {
 	accountsOnRollupA := make([]*accounts.Account, numOfAccountsForMultipleTxs)
 	accountsOnRollupB := make([]*accounts.Account, numOfAccountsForMultipleTxs)
 	for i := range numOfAccountsForMultipleTxs {
-		pk, err := crypto.GenerateKey()
-		require.NoError(t, err)
-		pkHex := hex.EncodeToString(crypto.FromECDSA(pk))
-		accountsOnRollupA[i], err = accounts.NewRollupAccount(pkHex, TestRollupA)
-		require.NoError(t, err)
-		accountsOnRollupB[i], err = accounts.NewRollupAccount(pkHex, TestRollupB)
-		require.NoError(t, err)
+		account := newRandomMultiChainAccount(t)
+		accountsOnRollupA[i], accountsOnRollupB[i] = account.On(TestRollupA), account.On(TestRollupB)
 	}
 
 	//distribute 0.1 eth to all accounts
 	logger.Info("Distributing 0.1 eth to all accounts...")
-	err := transactions.DistributeEth(ctx, TestAccountA, accountsOnRollupA, big.NewInt(100000000000000000))
+	fundedAmount := big.NewInt(100000000000000000)
+	fundedA, err := accounts.NewBalanceSnapshot(ctx, tokenAddress, TokenABI, accountsOnRollupA...)
+	require.NoError(t, err)
+	err = transactions.DistributeEth(ctx, TestAccountA, accountsOnRollupA, fundedAmount)
 	require.NoError(t, err)
-	err = transactions.DistributeEth(ctx, TestAccountB, accountsOnRollupB, big.NewInt(100000000000000000))
+	err = transactions.DistributeEth(ctx, TestAccountB, accountsOnRollupB, fundedAmount)
 	require.NoError(t, err)
+	// the fresh accounts have not sent anything yet, so they received exactly the funded ETH
+	expectedFunding := make(map[common.Address]accounts.Delta, len(accountsOnRollupA))
+	for _, acc := range accountsOnRollupA {
+		expectedFunding[acc.GetAddress()] = accounts.Delta{Token: big.NewInt(0), Eth: fundedAmount}
+	}
+	fundedA.AssertDeltas(t, expectedFunding)
 
 	// get needed mint amount
 	transferredAmount := big.NewInt(1000000000000000000)                                         // 1 token
@@ -238,55 +282,43 @@ func TestStressMultipleAccountsAndMultipleTxs(t *testing.T) {
 		require.NoError(t, err)
 	}
 
-	// nonces
-	var noncesA []uint64
-	var noncesB []uint64
+	// sync nonce managers with the chain
 	for i := 0; i < numOfAccountsForMultipleTxs; i++ {
-		// get nonce for both accounts
-		nonceA, err := accountsOnRollupA[i].GetNonce(ctx)
-		noncesA = append(noncesA, nonceA)
-		require.NoError(t, err)
-		nonceB, err := accountsOnRollupB[i].GetNonce(ctx)
-		noncesB = append(noncesB, nonceB)
-		require.NoError(t, err)
+		require.NoError(t, accountsOnRollupA[i].Nonces().Reset(ctx))
+		require.NoError(t, accountsOnRollupB[i].Nonces().Reset(ctx))
 	}
 
-	// send bridge txs
-	var txs_A []*types.Transaction
-	var txs_B []*types.Transaction
+	// build bridge txs
+	var batch transactions.BatchResult
+	var crossTxMsgs [][]byte
 
 	// for each account on A
 	for i := range accountsOnRollupA {
 		// for each tx to be sent
 		for j := 0; j < numOfTxsForMultipleAccounts; j++ {
 			// build bridge txs with different nonces
-			txA, txB, err := helpers.SendBridgeTxWithNonce(t, accountsOnRollupA[i], noncesA[i]+uint64(j), accountsOnRollupB[i], noncesB[i]+uint64(j), transferredAmount, TokenABI, BridgeABI)
+			nonceA, err := accountsOnRollupA[i].Nonces().Next(ctx)
+			require.NoError(t, err)
+			nonceB, err := accountsOnRollupB[i].Nonces().Next(ctx)
 			require.NoError(t, err)
+			txA, txB, _, _, msg := helpers.CreateBridgeTxWithNonce(t, accountsOnRollupA[i], nonceA, accountsOnRollupB[i], nonceB, transferredAmount, TokenABI, BridgeABI)
 			require.NotNil(t, txA)
 			require.NotNil(t, txB)
-			txs_A = append(txs_A, txA)
-			txs_B = append(txs_B, txB)
-			time.Sleep(delay)
+			batch.Add(txA, TestRollupA, transactions.ExpectSuccess)
+			batch.Add(txB, TestRollupB, transactions.ExpectSuccess)
+			crossTxMsgs = append(crossTxMsgs, msg)
 		}
 	}
 
-	// wait 30s until we check the txs
-	logger.Info("Waiting 30s until we check the txs...")
-	time.Sleep(30 * time.Second)
-	// check if all txs are successful
-	for _, tx := range txs_A {
-		_, receipt, err := transactions.GetTransactionDetails(ctx, tx.Hash(), TestRollupA)
-		require.NoError(t, err)
-		require.NotNil(t, receipt)
-		require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status, "tx %s", tx.Hash().Hex())
-	}
-	for _, tx := range txs_B {
-		_, receipt, err := transactions.GetTransactionDetails(ctx, tx.Hash(), TestRollupB)
+	// send them with up to numOfAccountsForMultipleTxs requests in flight
+	logger.Info("Sending %d bridge txs...", len(crossTxMsgs))
+	for _, err := range transactions.SendCrossTxBatch(ctx, crossTxMsgs, TestRollupA.RPCURL(), numOfAccountsForMultipleTxs) {
 		require.NoError(t, err)
-		require.NotNil(t, receipt)
-		require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status, "tx %s", tx.Hash().Hex())
 	}
 
+	// check if all txs are successful
+	verifyBatch(t, &batch, receiptTimeout)
+
 	// expected balances
 	for _, acc := range accountsOnRollupA {
 		balance, err := acc.GetTokensBalance(ctx, tokenAddress, TokenABI)
@@ -302,11 +334,10 @@ func TestStressMultipleAccountsAndMultipleTxs(t *testing.T) {
 }
 
 /*
-TestStressAtoBAndBtoA will use 1 account <numOfTxs> txs from A to B and B to A with delay between them.
+TestStressAtoBAndBtoA will use 1 account to bridge <numOfTxs> txs from A to B and B to A and check that balances are conserved.
 */
 func TestStressAtoBAndBtoA(t *testing.T) {
 	ctx := t.Context()
-	tokenAddress := configs.Values.L2.Contracts[configs.ContractNameToken].Address
 
 	mintedAndTransferredAmount := big.NewInt(1000000000000000000) // 1 token
 
@@ -316,99 +347,10 @@ func TestStressAtoBAndBtoA(t *testing.T) {
 	require.NotNil(t, tx)
 	require.NotNil(t, hash)
 
-	// get initial balances
-	initialBalanceA, err := TestAccountA.GetTokensBalance(ctx, tokenAddress, TokenABI)
-	require.NoError(t, err)
-	require.NotNil(t, initialBalanceA)
-	initialBalanceB, err := TestAccountB.GetTokensBalance(ctx, tokenAddress, TokenABI)
-	require.NoError(t, err)
-	require.NotNil(t, initialBalanceB)
-
-	// get nonces for both accounts
-	nonceA, err := TestAccountA.GetNonce(ctx)
-	require.NoError(t, err)
-	nonceB, err := TestAccountB.GetNonce(ctx)
-	require.NoError(t, err)
-
-	// send bridge txs from A to B and B to A with increasing nonce.
-	// Track legs per rollup so we query the correct chain later.
-	var txs_AtoB_A []*types.Transaction
-	var txs_AtoB_B []*types.Transaction
-	var txs_BtoA_A []*types.Transaction
-	var txs_BtoA_B []*types.Transaction
-
 	// totalNumOfTxs is half of numOfTxs, rounded down (e.g., 25 -> 12)
 	totalNumOfTxs := numOfTxs / 2
-	for i := 0; i < totalNumOfTxs; i++ {
-		// Interleave nonces per chain so we never reuse the same nonce
-		// for different bridge directions on the same account:
-		// - A nonces: nonceA + 2*i      (A->B) and nonceA + 2*i+1      (B->A leg)
-		// - B nonces: nonceB + 2*i      (A->B leg) and nonceB + 2*i+1  (B->A)
-		aNonceAtoB := nonceA + uint64(2*i)
-		bNonceAtoB := nonceB + uint64(2*i)
-		bNonceBtoA := nonceB + uint64(2*i+1)
-		aNonceBtoA := nonceA + uint64(2*i+1)
-
-		// Bridge from A to B
-		txA, txB, err := helpers.SendBridgeTxWithNonce(t, TestAccountA, aNonceAtoB, TestAccountB, bNonceAtoB, mintedAndTransferredAmount, TokenABI, BridgeABI)
-		txs_AtoB_A = append(txs_AtoB_A, txA)
-		txs_AtoB_B = append(txs_AtoB_B, txB)
-		require.NoError(t, err)
-		require.NotNil(t, txA)
-		require.NotNil(t, txB)
-		time.Sleep(delay)
-
-		// Bridge from B back to A
-		txB, txA, err = helpers.SendBridgeTxWithNonce(t, TestAccountB, bNonceBtoA, TestAccountA, aNonceBtoA, mintedAndTransferredAmount, TokenABI, BridgeABI)
-		txs_BtoA_B = append(txs_BtoA_B, txB)
-		txs_BtoA_A = append(txs_BtoA_A, txA)
-		require.NoError(t, err)
-		require.NotNil(t, txA)
-		require.NotNil(t, txB)
-		time.Sleep(delay)
-	}
-
-	// wait 30s until we check the txs
-	logger.Info("Waiting 30s until we check the txs...")
-	time.Sleep(30 * time.Second)
-	// A→B legs
-	for _, tx := range txs_AtoB_A {
-		_, receipt, err := transactions.GetTransactionDetails(ctx, tx.Hash(), TestRollupA)
-		require.NoError(t, err)
-		require.NotNil(t, receipt)
-		require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status, "tx %s", tx.Hash().Hex())
-	}
-	for _, tx := range txs_AtoB_B {
-		_, receipt, err := transactions.GetTransactionDetails(ctx, tx.Hash(), TestRollupB)
-		require.NoError(t, err)
-		require.NotNil(t, receipt)
-		require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status, "tx %s", tx.Hash().Hex())
-	}
-
-	// B→A legs
-	for _, tx := range txs_BtoA_A {
-		_, receipt, err := transactions.GetTransactionDetails(ctx, tx.Hash(), TestRollupA)
-		require.NoError(t, err)
-		require.NotNil(t, receipt)
-		require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status, "tx %s", tx.Hash().Hex())
-	}
-	for _, tx := range txs_BtoA_B {
-		_, receipt, err := transactions.GetTransactionDetails(ctx, tx.Hash(), TestRollupB)
-		require.NoError(t, err)
-		require.NotNil(t, receipt)
-		require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status, "tx %s", tx.Hash().Hex())
-	}
-
-	// expected balances
-	balanceAAfter, err := TestAccountA.GetTokensBalance(ctx, tokenAddress, TokenABI)
-	require.NoError(t, err)
-	require.NotNil(t, balanceAAfter)
-	balanceBAfter, err := TestAccountB.GetTokensBalance(ctx, tokenAddress, TokenABI)
-	require.NoError(t, err)
-	require.NotNil(t, balanceBAfter)
-	// should be the same as initial balance because we transferred the same amount of tokens back and forth
-	require.Equal(t, initialBalanceA, balanceAAfter)
-	require.Equal(t, initialBalanceB, balanceBAfter)
+	// balances should end where they started because we transfer the same amount of tokens back and forth
+	require.NoError(t, helpers.BridgeRoundTrip(ctx, TestAccountA, TestAccountB, mintedAndTransferredAmount, totalNumOfTxs, TokenABI, BridgeABI))
 }
 
 /*
@@ -428,78 +370,50 @@ func TestStressNormalTxsMixWithCrossRollupTxs(t *testing.T) {
 	require.NotNil(t, hash)
 
 	// get initial balances
-	initialBalanceA, err := TestAccountA.GetTokensBalance(ctx, tokenAddress, TokenABI)
+	snapshotA, err := accounts.NewBalanceSnapshot(ctx, tokenAddress, TokenABI, TestAccountA)
 	require.NoError(t, err)
-	require.NotNil(t, initialBalanceA)
-	initialBalanceB, err := TestAccountB.GetTokensBalance(ctx, tokenAddress, TokenABI)
+	snapshotB, err := accounts.NewBalanceSnapshot(ctx, tokenAddress, TokenABI, TestAccountB)
 	require.NoError(t, err)
-	require.NotNil(t, initialBalanceB)
 
-	// get nonces for both accounts
-	nonceA, err := TestAccountA.GetNonce(ctx)
-	require.NoError(t, err)
-	nonceB, err := TestAccountB.GetNonce(ctx)
-	require.NoError(t, err)
+	// sync nonce managers with the chain
+	require.NoError(t, TestAccountA.Nonces().Reset(ctx))
+	require.NoError(t, TestAccountB.Nonces().Reset(ctx))
 
 	// send self move balance tx and bridge tx alternatively with increasing nonce and with delay between them
-	var txs_selfMoveBalance []*types.Transaction
-	var txs_bridgeTxA []*types.Transaction
-	var txs_bridgeTxB []*types.Transaction
+	var batch transactions.BatchResult
 
 	selfMoveBalanceAmount := big.NewInt(100000000000000000) // 0.1 eth
 	for i := 0; i < numOfTxs; i++ {
 		// Interleave nonces so we never replace a bridge tx with a self-move tx:
-		// self-move uses even offsets, bridge uses odd offsets.
-		selfNonceA := nonceA + uint64(2*i)
-		bridgeNonceA := nonceA + uint64(2*i+1)
-		bridgeNonceB := nonceB + uint64(i)
+		// the self-move takes the next nonce on A, then the bridge takes the one after.
+		selfNonceA, err := TestAccountA.Nonces().Next(ctx)
+		require.NoError(t, err)
+		bridgeNonceA, err := TestAccountA.Nonces().Next(ctx)
+		require.NoError(t, err)
+		bridgeNonceB, err := TestAccountB.Nonces().Next(ctx)
+		require.NoError(t, err)
 
 		// Self-move balance tx on rollup A
 		tx, hash, err := helpers.SendSelfMoveBalanceTxWithNonce(ctx, TestAccountA, selfNonceA, selfMoveBalanceAmount)
 		require.NoError(t, err)
 		require.NotNil(t, tx)
 		require.NotNil(t, hash)
-		txs_selfMoveBalance = append(txs_selfMoveBalance, tx)
+		batch.Add(tx, TestRollupA, transactions.ExpectSuccess)
 		time.Sleep(delay)
 
 		// Cross-rollup bridge tx (A -> B)
-		txA, txB, err := helpers.SendBridgeTxWithNonce(t, TestAccountA, bridgeNonceA, TestAccountB, bridgeNonceB, transferedAmount, TokenABI, BridgeABI)
+		txA, txB, _, _, err := helpers.SendBridgeTxWithNonce(t, TestAccountA, bridgeNonceA, TestAccountB, bridgeNonceB, transferedAmount, TokenABI, BridgeABI)
 		require.NoError(t, err)
 		require.NotNil(t, txA)
 		require.NotNil(t, txB)
-		txs_bridgeTxA = append(txs_bridgeTxA, txA)
-		txs_bridgeTxB = append(txs_bridgeTxB, txB)
+		batch.Add(txA, TestRollupA, transactions.ExpectSuccess)
+		batch.Add(txB, TestRollupB, transactions.ExpectSuccess)
 		time.Sleep(delay)
 	}
 
-	// wait 30s until we check the txs
-	logger.Info("Waiting 30s until we check the txs...")
-	time.Sleep(30 * time.Second)
-	for _, tx := range txs_selfMoveBalance {
-		_, receipt, err := transactions.GetTransactionDetails(ctx, tx.Hash(), TestRollupA)
-		require.NoError(t, err)
-		require.NotNil(t, receipt)
-		require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status, "tx %s", tx.Hash().Hex())
-	}
-	for _, tx := range txs_bridgeTxA {
-		_, receipt, err := transactions.GetTransactionDetails(ctx, tx.Hash(), TestRollupA)
-		require.NoError(t, err)
-		require.NotNil(t, receipt)
-		require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status, "tx %s", tx.Hash().Hex())
-	}
-	for _, tx := range txs_bridgeTxB {
-		_, receipt, err := transactions.GetTransactionDetails(ctx, tx.Hash(), TestRollupB)
-		require.NoError(t, err)
-		require.NotNil(t, receipt)
-		require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status, "tx %s", tx.Hash().Hex())
-	}
+	verifyBatch(t, &batch, receiptTimeout)
 
 	// expected balances
-	balanceAAfter, err := TestAccountA.GetTokensBalance(ctx, tokenAddress, TokenABI)
-	require.NoError(t, err)
-	balanceBAfter, err := TestAccountB.GetTokensBalance(ctx, tokenAddress, TokenABI)
-	require.NoError(t, err)
-	require.NotNil(t, balanceBAfter)
-	require.Equal(t, new(big.Int).Sub(initialBalanceA, mintedAmount), balanceAAfter)
-	require.Equal(t, new(big.Int).Add(initialBalanceB, mintedAmount), balanceBAfter)
+	snapshotA.AssertDeltas(t, map[common.Address]accounts.Delta{TestAccountA.GetAddress(): {Token: new(big.Int).Neg(mintedAmount)}})
+	snapshotB.AssertDeltas(t, map[common.Address]accounts.Delta{TestAccountB.GetAddress(): {Token: mintedAmount}})
 }
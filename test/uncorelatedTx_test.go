@@ -40,8 +40,8 @@ func TestTxASuccessAndTxBFailure(t *testing.T) {
 		To:        TestAccountA.GetAddress(),
 		Value:     balanceA.Div(balanceA, big.NewInt(2)), // less than balanceA
 		Gas:       900000,
-		GasTipCap: big.NewInt(1000000000),
-		GasFeeCap: big.NewInt(20000000000),
+		GasTipCap: transactions.GWei(1),
+		GasFeeCap: transactions.GWei(20),
 		Data:      nil,
 	}
 
@@ -55,8 +55,8 @@ func TestTxASuccessAndTxBFailure(t *testing.T) {
 		To:        TestAccountB.GetAddress(),
 		Value:     balanceB.Add(balanceB, big.NewInt(1000000000000000000)), // more than balanceB
 		Gas:       900000,
-		GasTipCap: big.NewInt(1000000000),
-		GasFeeCap: big.NewInt(20000000000),
+		GasTipCap: transactions.GWei(1),
+		GasFeeCap: transactions.GWei(20),
 		Data:      nil,
 	}
 
@@ -71,7 +71,7 @@ func TestTxASuccessAndTxBFailure(t *testing.T) {
 	require.NotNil(t, crossTxRequestMsg)
 
 	// send cross tx request msg
-	err = transactions.SendCrossTxRequestMsg(ctx, TestRollupA.RPCURL(), crossTxRequestMsg)
+	_, err = transactions.SendCrossTxRequestMsg(ctx, TestRollupA.RPCURL(), crossTxRequestMsg)
 	require.NoError(t, err)
 
 	// wait for 10 seconds before checking txs